@@ -0,0 +1,348 @@
+// Package analyzer define an analyzer that check the struct tags of qbuilder param structs.
+//
+// A struct is a param struct if any of its fields has tag:"param". The analyzer report:
+//   - unknown operator suffix, e.g: param:"age__gtee"
+//   - operator that doesn't match the field type, e.g: param:"ids__gt" on a slice or param:"age__regex" on an int
+//   - invalid empty, trunc, unit and agg tags
+//   - missing or empty db tag, the field is silently skipped by qbuilder
+//   - unsupported field type
+//   - duplicate param keys
+//   - page, limit, sort, with_deleted and only_deleted params with the wrong type, and invalid default or max
+//   - json_key on a non string field
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check the struct tags of qbuilder param structs
+
+A struct is a param struct if any of its fields has a param tag. Unknown operator
+suffixes, unsupported types, missing db tags, duplicate param keys and misuse of
+the page, limit and sort params are reported.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "qbuildervet",
+	Doc:      doc,
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// custom operators, e.g: -operators=bitand,near
+var customOperators string
+
+func init() {
+	Analyzer.Flags.StringVar(&customOperators, "operators", "", "comma separated custom operators registered with qbuilder, without the __ prefix")
+}
+
+// builtin operators and tag values of qbuilder, kept in sync by the qbuilder tests
+var (
+	scalarOperators = []string{"gt", "gte", "lt", "lte", "neq", "search", "regex", "iregex", "iexact"}
+	sliceOperators  = []string{"nin", "like", "overlap", "contains_all", "any"}
+	stringOperators = []string{"search", "regex", "iregex", "iexact", "like"}
+
+	emptyPolicies = []string{"skip", "none", "error"}
+	truncUnits    = []string{"hour", "day", "month"}
+	durationUnits = []string{"ns", "us", "ms", "s", "m", "h"}
+	aggFuncs      = []string{"count", "sum", "avg", "min", "max"}
+)
+
+// supported named types, by package path and name
+var supportedNamed = map[string]bool{
+	"time.Time":                                  true,
+	"time.Duration":                              true,
+	"database/sql.NullTime":                      true,
+	"database/sql.NullString":                    true,
+	"database/sql.NullInt32":                     true,
+	"database/sql.NullInt64":                     true,
+	"database/sql.NullFloat64":                   true,
+	"database/sql.NullBool":                      true,
+	"github.com/tuingking/qbuilder.RelativeTime": true,
+	"github.com/tuingking/qbuilder.Point":        true,
+	"github.com/tuingking/qbuilder.Radius":       true,
+	"github.com/tuingking/qbuilder.BBox":         true,
+}
+
+// time types, accepted by tag:"trunc"
+var timeNamed = map[string]bool{
+	"time.Time":             true,
+	"database/sql.NullTime": true,
+	"github.com/tuingking/qbuilder.RelativeTime": true,
+}
+
+// geo types, no operator is supported
+var geoNamed = map[string]bool{
+	"github.com/tuingking/qbuilder.Point":  true,
+	"github.com/tuingking/qbuilder.Radius": true,
+	"github.com/tuingking/qbuilder.BBox":   true,
+}
+
+// supported slice element named types
+var supportedSliceNamed = map[string]bool{
+	"time.Duration": true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{(*ast.StructType)(nil)}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		checkStruct(pass, n.(*ast.StructType))
+	})
+
+	return nil, nil
+}
+
+func checkStruct(pass *analysis.Pass, st *ast.StructType) {
+	if !hasParamTag(st) {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range st.Fields.List {
+		tag := fieldTag(field)
+		param, _ := parseTag(tag.Get("param"))
+		if param == "" || param == "-" {
+			continue
+		}
+
+		if seen[param] {
+			pass.Reportf(field.Pos(), "duplicate param %q", param)
+		}
+		seen[param] = true
+
+		typ := pass.TypesInfo.TypeOf(field.Type)
+		if typ == nil {
+			continue
+		}
+
+		switch param {
+		case "page", "limit":
+			if !isBasic(typ, types.Int, types.Int64) {
+				pass.Reportf(field.Pos(), "param %q should be int or int64, got %s", param, typ)
+			}
+			for _, key := range []string{"default", "max"} {
+				if v := tag.Get(key); v != "" {
+					if n, err := strconv.ParseInt(v, 10, 64); err != nil || n <= 0 {
+						pass.Reportf(field.Pos(), "param %q tag %s should be a positive integer, got %q", param, key, v)
+					}
+				}
+			}
+			continue
+		case "sort_by", "sort", "short_by":
+			if !isBasic(typ, types.String) && !isStringSlice(typ) {
+				pass.Reportf(field.Pos(), "param %q should be []string or string, got %s", param, typ)
+			}
+			continue
+		case "with_deleted", "only_deleted":
+			if !isBasic(typ, types.Bool) && !isBasic(pointerElem(typ), types.Bool) {
+				pass.Reportf(field.Pos(), "param %q should be bool or *bool, got %s", param, typ)
+			}
+			continue
+		}
+
+		db, _ := parseTag(tag.Get("db"))
+		if db == "" {
+			pass.Reportf(field.Pos(), "param %q has no db tag, the field is skipped", param)
+			continue
+		}
+		if db == "-" {
+			continue
+		}
+
+		if !isSupported(typ) {
+			pass.Reportf(field.Pos(), "param %q has unsupported type %s", param, typ)
+			continue
+		}
+
+		if tag.Get("json_key") != "" && !isString(typ) {
+			pass.Reportf(field.Pos(), "json_key is only supported for string, got %s", typ)
+		}
+
+		checkOperator(pass, field, param, typ)
+		checkTags(pass, field, param, tag, typ)
+	}
+}
+
+// checkTags check the tag values of the filter field.
+func checkTags(pass *analysis.Pass, field *ast.Field, param string, tag reflect.StructTag, typ types.Type) {
+	if v := tag.Get("empty"); v != "" && !contains(emptyPolicies, v) {
+		pass.Reportf(field.Pos(), "unknown empty policy %q, should be skip, none or error", v)
+	}
+
+	if v := tag.Get("trunc"); v != "" {
+		switch {
+		case !timeNamed[namedType(pointerElem(typ))]:
+			pass.Reportf(field.Pos(), "trunc is only supported for time, got %s", typ)
+		case !contains(truncUnits, v):
+			pass.Reportf(field.Pos(), "unknown trunc %q, should be hour, day or month", v)
+		}
+	}
+
+	if v := tag.Get("unit"); v != "" && !contains(durationUnits, v) {
+		pass.Reportf(field.Pos(), "unknown duration unit %q, should be ns, us, ms, s, m or h", v)
+	}
+
+	if v := tag.Get("agg"); v != "" && !contains(aggFuncs, strings.ToLower(v)) {
+		pass.Reportf(field.Pos(), "unsupported aggregate %q", v)
+	}
+
+	_, opts := parseTag(tag.Get("param"))
+	if contains(opts, "required") && contains(opts, "omitempty") {
+		pass.Reportf(field.Pos(), "param %q: omitempty and required cannot be used together", param)
+	}
+}
+
+func checkOperator(pass *analysis.Pass, field *ast.Field, param string, typ types.Type) {
+	i := strings.LastIndex(param, "__")
+	if i < 0 {
+		return
+	}
+	op := param[i+2:]
+
+	if geoNamed[namedType(pointerElem(typ))] {
+		pass.Reportf(field.Pos(), "operator %q is not supported for %s", op, typ)
+		return
+	}
+
+	if contains(stringOperators, op) && !isString(elemType(typ)) {
+		pass.Reportf(field.Pos(), "operator %q is not supported for %s", op, typ)
+		return
+	}
+
+	_, isSlice := typ.Underlying().(*types.Slice)
+	switch {
+	case contains(scalarOperators, op):
+		if isSlice {
+			pass.Reportf(field.Pos(), "operator %q is not supported for slice %s", op, typ)
+		}
+	case contains(sliceOperators, op):
+		if !isSlice {
+			pass.Reportf(field.Pos(), "operator %q is only supported for slice, got %s", op, typ)
+		}
+	case contains(strings.Split(customOperators, ","), op):
+	default:
+		pass.Reportf(field.Pos(), "unknown operator %q in param %q", op, param)
+	}
+}
+
+func hasParamTag(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if _, ok := fieldTag(field).Lookup("param"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldTag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(tag)
+}
+
+// isSupported report whether qbuilder can render the type, a pointer to a non slice type is supported.
+func isSupported(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		if _, ok := ptr.Elem().Underlying().(*types.Slice); ok {
+			return false
+		}
+		typ = ptr.Elem()
+	}
+
+	if slice, ok := typ.(*types.Slice); ok {
+		if named := namedType(slice.Elem()); named != "" {
+			return supportedSliceNamed[named]
+		}
+		// []byte is not a list
+		return isBasic(slice.Elem(), types.String, types.Bool, types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+			types.Uint, types.Uint16, types.Uint32, types.Uint64, types.Float32, types.Float64)
+	}
+
+	if named := namedType(typ); named != "" {
+		return supportedNamed[named]
+	}
+
+	return isBasic(typ, types.String, types.Bool, types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+		types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64, types.Float32, types.Float64)
+}
+
+// namedType return the package path and name of the named type, e.g: time.Time
+func namedType(typ types.Type) string {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+}
+
+// isBasic report whether typ is one of the basic kinds, named types are not basic.
+func isBasic(typ types.Type, kinds ...types.BasicKind) bool {
+	basic, ok := typ.(*types.Basic)
+	if !ok {
+		return false
+	}
+	for _, k := range kinds {
+		if basic.Kind() == k {
+			return true
+		}
+	}
+	return false
+}
+
+// elemType return the element of the pointer or slice type, or typ itself.
+func elemType(typ types.Type) types.Type {
+	if slice, ok := typ.(*types.Slice); ok {
+		return slice.Elem()
+	}
+	return pointerElem(typ)
+}
+
+// pointerElem return the element of the pointer type, or typ itself.
+func pointerElem(typ types.Type) types.Type {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return typ
+}
+
+// isString report whether typ is string, *string or sql.NullString.
+func isString(typ types.Type) bool {
+	return isBasic(pointerElem(typ), types.String) || namedType(typ) == "database/sql.NullString"
+}
+
+func isStringSlice(typ types.Type) bool {
+	slice, ok := typ.(*types.Slice)
+	return ok && isBasic(slice.Elem(), types.String)
+}
+
+// parseTag split the struct tag into name and options.
+func parseTag(tag string) (string, []string) {
+	name, opts, found := strings.Cut(tag, ",")
+	if !found {
+		return name, nil
+	}
+	return name, strings.Split(opts, ",")
+}
+
+func contains(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
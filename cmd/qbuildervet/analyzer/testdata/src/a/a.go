@@ -0,0 +1,71 @@
+package a
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/tuingking/qbuilder"
+)
+
+type Param struct {
+	Page      int64             `param:"page"`
+	Limit     string            `param:"limit"` // want `param "limit" should be int or int64, got string`
+	SortBy    []string          `param:"short_by"`
+	Name      string            `param:"name" db:"name"`
+	Age       int               `param:"age__gtee" db:"age"` // want `unknown operator "gtee" in param "age__gtee"`
+	AgeLTE    *int              `param:"age__lte,omitempty" db:"age"`
+	Status    string            `param:"status"`      // want `param "status" has no db tag, the field is skipped`
+	Email     string            `param:"email" db:""` // want `param "email" has no db tag, the field is skipped`
+	Skipped   string            `param:"skipped" db:"-"`
+	Meta      map[string]string `param:"meta" db:"meta"`         // want `param "meta" has unsupported type map\[string\]string`
+	Name2     string            `param:"name" db:"name"`         // want `duplicate param "name"`
+	IDs       []int64           `param:"id__gt" db:"id"`         // want `operator "gt" is not supported for slice \[\]int64`
+	Shop      int64             `param:"shop__nin" db:"shop_id"` // want `operator "nin" is only supported for slice, got int64`
+	Tags      []string          `param:"tags__overlap" db:"tags"`
+	Flag      int               `param:"flag" db:"data" json_key:"flag"` // want `json_key is only supported for string, got int`
+	Color     sql.NullString    `param:"color" db:"data" json_key:"color"`
+	Size      *string           `param:"size" db:"data" json_key:"size"`
+	CreatedAt time.Time         `param:"created_at__gte" db:"created_at"`
+	DeletedAt sql.NullTime      `param:"deleted_at" db:"deleted_at"`
+	Timeouts  []time.Duration   `param:"timeout" db:"timeout"`
+	Raw       []byte            `param:"raw" db:"raw"` // want `param "raw" has unsupported type \[\]byte`
+	NotParam  string            `json:"not_param"`
+}
+
+type SoftDeleteParam struct {
+	WithDeleted bool   `param:"with_deleted"`
+	OnlyDeleted *bool  `param:"only_deleted"`
+	Deleted     string `param:"with_deleted"` // want `duplicate param "with_deleted"` `param "with_deleted" should be bool or \*bool, got string`
+}
+
+type KindParam struct {
+	Age    int            `param:"age__regex" db:"age"` // want `operator "regex" is not supported for int`
+	N      sql.NullInt64  `param:"n__search" db:"n"`    // want `operator "search" is not supported for database/sql.NullInt64`
+	F      *float64       `param:"f__iexact" db:"f"`    // want `operator "iexact" is not supported for \*float64`
+	IDs    []int64        `param:"id__like" db:"id"`    // want `operator "like" is not supported for \[\]int64`
+	Name   sql.NullString `param:"name__iregex" db:"name"`
+	Email  *string        `param:"email__iexact" db:"email"`
+	Names  []string       `param:"names__like" db:"names"`
+	Origin qbuilder.Point `param:"origin__gt" db:"location"` // want `operator "gt" is not supported for github.com/tuingking/qbuilder.Point`
+	Near   qbuilder.Point `param:"near" db:"location"`
+}
+
+type TagParam struct {
+	Page     int64                 `param:"page" max:"ten"`                   // want `param "page" tag max should be a positive integer, got "ten"`
+	Limit    int64                 `param:"limit" default:"0"`                // want `param "limit" tag default should be a positive integer, got "0"`
+	IDs      []int64               `param:"id" db:"id" empty:"all"`           // want `unknown empty policy "all", should be skip, none or error`
+	Day      time.Time             `param:"day" db:"created_at" trunc:"week"` // want `unknown trunc "week", should be hour, day or month`
+	Name     string                `param:"name" db:"name" trunc:"day"`       // want `trunc is only supported for time, got string`
+	Since    qbuilder.RelativeTime `param:"since" db:"created_at" trunc:"day"`
+	Deleted  *sql.NullTime         `param:"deleted" db:"deleted_at" trunc:"hour"`
+	Timeout  time.Duration         `param:"timeout" db:"timeout_ms" unit:"sec"`  // want `unknown duration unit "sec", should be ns, us, ms, s, m or h`
+	Total    float64               `param:"total__gte" db:"amount" agg:"median"` // want `unsupported aggregate "median"`
+	Count    int64                 `param:"count__gte" db:"id" agg:"COUNT"`
+	Status   string                `param:"status,required,omitempty" db:"status"` // want `param "status": omitempty and required cannot be used together`
+	Category string                `param:"category,required" db:"category" empty:"none"`
+}
+
+type NotParam struct {
+	Name string `db:"name"`
+	Age  int    `json:"age"`
+}
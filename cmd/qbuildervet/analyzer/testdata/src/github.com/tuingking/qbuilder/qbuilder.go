@@ -0,0 +1,6 @@
+// Package qbuilder is a stub of the qbuilder types used by the analyzer tests.
+package qbuilder
+
+type RelativeTime struct{}
+
+type Point struct{ Lat, Lng float64 }
@@ -0,0 +1,18 @@
+// Command qbuildervet check the struct tags of qbuilder param structs.
+//
+// It can be run standalone or with go vet:
+//
+//	go install github.com/tuingking/qbuilder/cmd/qbuildervet
+//	qbuildervet ./...
+//	go vet -vettool=$(which qbuildervet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/tuingking/qbuilder/cmd/qbuildervet/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -2,6 +2,7 @@ package qbuilder
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -10,19 +11,48 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
 type cursor struct {
-	field   reflect.Value // struct field
-	param   string        // tag:"param"
-	db      string        // tag:"db"
-	jsonKey string        // tag:"json_key"
+	field   reflect.Value     // struct field
+	tag     reflect.StructTag // struct tag
+	qb      *queryBuilder     // options, e.g: dialect
+	param   string            // tag:"param", without the options
+	opts    tagOptions        // tag:"param" options, e.g: param:"age,omitempty"
+	op      string            // tag:"param" operator, e.g: param:"age__gte" => gte
+	db      string            // tag:"db"
+	jsonKey string            // tag:"json_key"
+	agg     string            // tag:"agg"
+	ptr     bool              // dereferenced from a non-nil pointer, the value is always rendered
+
+	// error while making the clause, e.g: sqlx.In error
+	err error
 }
 
-func newCursor(field reflect.Value, param, db, jsonKey string) cursor {
+func newCursor(field reflect.Value, tag reflect.StructTag, qb *queryBuilder) cursor {
+	db, _ := parseTag(tag.Get("db"))
+	param, opts := parseTag(tag.Get("param"))
+	_, op := parseParam(param)
+
 	return cursor{
 		field:   field,
+		tag:     tag,
+		qb:      qb,
 		param:   param,
+		opts:    opts,
+		op:      op,
 		db:      db,
-		jsonKey: jsonKey,
+		jsonKey: tag.Get("json_key"),
+		agg:     tag.Get("agg"),
 	}
 }
 
@@ -34,8 +64,15 @@ func (c *cursor) IsLimit() bool {
 	return c.param == "limit"
 }
 
+// IsSortBy report whether the field is the sort param,
+// short_by is kept for backward compatibility.
 func (c *cursor) IsSortBy() bool {
-	return c.param == "short_by"
+	switch c.param {
+	case "sort_by", "sort", "short_by":
+		return true
+	default:
+		return false
+	}
 }
 
 func (c *cursor) IsEmpty() bool {
@@ -48,58 +85,299 @@ func (c *cursor) IsEmpty() bool {
 	return false
 }
 
-func (c *cursor) GetOperand() string {
-	operand := "="
-
-	// update operand
-	switch param := c.param; {
-	case strings.HasSuffix(param, "__gt"):
-		operand = ">"
-	case strings.HasSuffix(param, "__gte"):
-		operand = ">="
-	case strings.HasSuffix(param, "__lt"):
-		operand = "<"
-	case strings.HasSuffix(param, "__lte"):
-		operand = "<="
-	case strings.HasSuffix(param, "__neq"):
-		operand = "!="
+// IsOmitEmpty report whether the zero value is skipped, e.g: param:"age,omitempty" or WithOmitEmpty
+func (c *cursor) IsOmitEmpty() bool {
+	return c.opts.Has("omitempty") || c.qb.omitEmpty
+}
+
+// IsRequired report whether the zero value is an error, e.g: param:"shop_id,required"
+func (c *cursor) IsRequired() bool {
+	return c.opts.Has("required")
+}
+
+// IsZero report whether the field is the zero value, a non-nil pointer or empty slice is not zero.
+func (c *cursor) IsZero() bool {
+	return c.field.IsZero()
+}
+
+func (c *cursor) IsAggregate() bool {
+	return c.agg != ""
+}
+
+func (c *cursor) IsValidAggregate() bool {
+	_, ok := aggFuncs[strings.ToLower(c.agg)]
+	return ok
+}
+
+// Column return the column expression, wrapped with the aggregate function if any.
+//
+// e.g: db:"amount" agg:"sum" => SUM(amount)
+func (c *cursor) Column() string {
+	if fn, ok := aggFuncs[strings.ToLower(c.agg)]; ok {
+		return fn + "(" + c.db + ")"
+	}
+	return c.db
+}
+
+// IsSearch report whether the field is a fulltext search, e.g: param:"q__search"
+func (c *cursor) IsSearch() bool {
+	return c.op == "search"
+}
+
+// SearchColumns return the fulltext columns from tag:"fulltext", default is the db column.
+//
+// e.g: `param:"q__search" db:"title" fulltext:"title,description"`
+func (c *cursor) SearchColumns() []string {
+	if tag := c.tag.Get("fulltext"); tag != "" {
+		return strings.Split(tag, ",")
+	}
+	return []string{c.db}
+}
+
+// SearchExpr return the fulltext search predicate.
+//
+// e.g:
+//
+//	MySQL:    MATCH(title, description) AGAINST (? IN BOOLEAN MODE)
+//	Postgres: to_tsvector(coalesce(title, '') || ' ' || coalesce(description, '')) @@ plainto_tsquery(?)
+func (c *cursor) SearchExpr() string {
+	if c.qb.dialect == Postgres {
+		return c.tsvector() + " @@ plainto_tsquery(?)"
+	}
+	return fmt.Sprintf(searchClauseMatchFmt, strings.Join(c.SearchColumns(), ", "))
+}
+
+// RelevanceExpr return the fulltext relevance score, to be used as sort key.
+func (c *cursor) RelevanceExpr() string {
+	if c.qb.dialect == Postgres {
+		return "ts_rank(" + c.tsvector() + ", plainto_tsquery(?))"
+	}
+	return fmt.Sprintf(searchClauseMatchFmt, strings.Join(c.SearchColumns(), ", "))
+}
+
+// DerivedSortKey return the sort key of tag:"sort_key", the relevance of the fulltext search
+// or the distance from the geo point. The key is omitted from ORDER BY when the field is not set.
+func (c *cursor) DerivedSortKey(args []interface{}, skip bool) (SortKey, bool) {
+	switch {
+	case c.IsSearch():
+		return SortKey{Expr: c.RelevanceExpr(), Args: args, derived: true, omit: skip}, true
+	case c.IsGeo():
+		origin, ok := c.geoOrigin()
+		expr, args := c.DistanceExpr(origin)
+		return SortKey{Expr: expr, Args: args, derived: true, omit: !ok}, true
 	default:
+		return SortKey{}, false
 	}
+}
 
-	return operand
+func (c *cursor) tsvector() string {
+	cols := c.SearchColumns()
+	if len(cols) == 1 {
+		return "to_tsvector(" + cols[0] + ")"
+	}
+
+	for i, col := range cols {
+		cols[i] = "coalesce(" + col + ", '')"
+	}
+	return "to_tsvector(" + strings.Join(cols, " || ' ' || ") + ")"
+}
+
+// IsRegex report whether the field is a regex match, e.g: param:"name__regex" or param:"name__iregex"
+func (c *cursor) IsRegex() bool {
+	return c.op == "regex" || c.op == "iregex"
+}
+
+// IsStringMatch report whether the field is a regex or case-insensitive match.
+func (c *cursor) IsStringMatch() bool {
+	return c.IsRegex() || c.op == "iexact"
+}
+
+// StringValue return the value of string, non-nil *string or valid sql.NullString field.
+func (c *cursor) StringValue() (string, bool) {
+	switch val := c.field.Interface().(type) {
+	case string:
+		return val, val != ""
+	case *string:
+		if val == nil {
+			return "", false
+		}
+		return *val, true
+	case sql.NullString:
+		return val.String, val.Valid
+	default:
+		return "", false
+	}
+}
+
+// IsSlice report whether the field is a slice, except []byte.
+func (c *cursor) IsSlice() bool {
+	return c.field.Kind() == reflect.Slice && c.field.Type().Elem().Kind() != reflect.Uint8
+}
+
+// IsScalarOperator report whether the operator suffix only accept a scalar value.
+func (c *cursor) IsScalarOperator() bool {
+	op, ok := lookupOperator(c.op)
+	return ok && c.op != "" && !op.IsSlice()
+}
+
+// IsSliceOperator report whether the operator suffix only accept a slice value.
+func (c *cursor) IsSliceOperator() bool {
+	op, ok := lookupOperator(c.op)
+	return ok && op.IsSlice()
+}
+
+// CheckOperator return the reason if the operator suffix is unknown or doesn't match the field type.
+func (c *cursor) CheckOperator() string {
+	op, ok := lookupOperator(c.op)
+	if !ok {
+		return fmt.Sprintf("unknown operator %q", c.op)
+	}
+
+	switch {
+	case c.IsGeo() && c.op != "":
+		return fmt.Sprintf("operator is not supported for %s", c.field.Type())
+	case !op.accept(c.elemKind()):
+		return fmt.Sprintf("operator %q is not supported for %s", c.op, c.field.Type())
+	case !op.builtin && c.tag.Get("trunc") != "":
+		return fmt.Sprintf("trunc is not supported by operator %q", c.op)
+	case c.IsSlice() && c.IsScalarOperator():
+		return fmt.Sprintf("operator is not supported for slice %s", c.field.Type())
+	case !c.IsSlice() && c.IsSliceOperator():
+		return fmt.Sprintf("operator is only supported for slice, got %s", c.field.Type())
+	case c.IsSlice() && c.op == "like" && c.field.Type() != reflect.TypeOf([]string(nil)):
+		return fmt.Sprintf("operator is only supported for []string, got %s", c.field.Type())
+	default:
+		return ""
+	}
+}
+
+// elemKind return the kind of the value, or the slice element, e.g: *int64 => int64, []string => string,
+// sql.NullString => string
+func (c *cursor) elemKind() reflect.Kind {
+	t := c.field.Type()
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	switch reflect.Zero(t).Interface().(type) {
+	case sql.NullString:
+		return reflect.String
+	case sql.NullInt64:
+		return reflect.Int64
+	case sql.NullInt32:
+		return reflect.Int32
+	case sql.NullFloat64:
+		return reflect.Float64
+	case sql.NullBool:
+		return reflect.Bool
+	default:
+		return t.Kind()
+	}
+}
+
+func (c *cursor) GetOperand() string {
+	if op, _ := lookupOperator(c.op); op.operand != "" && !op.IsSlice() {
+		return op.operand
+	}
+	return "="
 }
 
 func (c *cursor) GetOperandMulti() string {
-	operand := "IN"
+	if c.op == "nin" {
+		return "NOT IN"
+	}
+	return "IN"
+}
+
+// IsSupported report whether the field type can be rendered by Make.
+// A pointer to a supported non-slice type is supported, e.g: *bool, *int64, *time.Time
+func (c *cursor) IsSupported() bool {
+	t := c.field.Type()
+	if t.Kind() == reflect.Ptr {
+		if t.Elem().Kind() == reflect.Slice {
+			return false
+		}
+		t = t.Elem()
+	}
+
+	switch reflect.Zero(t).Interface().(type) {
+	case string, bool, int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64, float32, float64,
+		time.Time, sql.NullTime, time.Duration, RelativeTime,
+		Point, Radius, BBox,
+		[]string, []bool, []int, []int8, []int16, []int32, []int64,
+		[]uint, []uint16, []uint32, []uint64, []float32, []float64, []time.Duration,
+		sql.NullString, sql.NullInt32, sql.NullInt64, sql.NullFloat64, sql.NullBool:
+		return true
+	default:
+		return false
+	}
+}
+
+// DurationUnit return the unit of time.Duration field from tag:"unit", default is nanosecond.
+//
+// e.g: `param:"timeout__gte" db:"timeout_ms" unit:"ms"` => time.Second is rendered as 1000
+func (c *cursor) DurationUnit() (time.Duration, error) {
+	tag := c.tag.Get("unit")
+	if tag == "" {
+		return time.Nanosecond, nil
+	}
 
-	// update operand
-	if strings.HasSuffix(c.param, "__nin") {
-		operand = "NOT IN"
-	} else {
-		// skip
+	unit, ok := durationUnits[tag]
+	if !ok {
+		return 0, fmt.Errorf("unknown duration unit %q, should be ns, us, ms, s, m or h", tag)
 	}
 
-	return operand
+	return unit, nil
 }
 
 func (c *cursor) Make() (clause string, args []interface{}, skip bool) {
 	skip = true
 
+	if c.field.Kind() == reflect.Ptr {
+		return c.makeClausePointer()
+	}
+
+	if op, _ := lookupOperator(c.op); !op.builtin {
+		return c.makeClauseOperator(op)
+	}
+
 	switch c.field.Interface().(type) {
-	case string, int, int32, int64, float32, float64:
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
 		clause, args, skip = c.makeClausePrimitiveType()
-	case time.Time, sql.NullTime:
+	case time.Time, sql.NullTime, time.Duration, RelativeTime:
 		clause, args, skip = c.makeClauseTimeType()
-	case []string, []int, []int32, []int64, []float32, []float64:
+	case []string, []bool, []int, []int8, []int16, []int32, []int64,
+		[]uint, []uint16, []uint32, []uint64, []float32, []float64, []time.Duration:
 		clause, args, skip = c.makeClauseArrayType()
 	case sql.NullString, sql.NullInt32, sql.NullInt64, sql.NullFloat64, sql.NullBool:
 		clause, args, skip = c.makeClauseSqlNullType()
+	case Point, Radius, BBox:
+		clause, args, skip = c.makeClauseGeoType()
 	default:
 	}
 
 	return
 }
 
+// makeClausePointer skip the nil pointer, otherwise make the clause of the pointed value.
+// The value of a non-nil pointer is always rendered, e.g: *bool false => col = false
+func (c *cursor) makeClausePointer() (clause string, args []interface{}, skip bool) {
+	if c.field.IsNil() {
+		skip = true
+		return
+	}
+
+	elem := *c
+	elem.field = c.field.Elem()
+	elem.ptr = true
+
+	clause, args, skip = elem.Make()
+	c.err = elem.err
+
+	return
+}
+
 func (c *cursor) makeClausePrimitiveType() (clause string, args []interface{}, skip bool) {
 	skip = true
 	operand := c.GetOperand()
@@ -107,7 +385,9 @@ func (c *cursor) makeClausePrimitiveType() (clause string, args []interface{}, s
 	switch val := c.field.Interface().(type) {
 	case string:
 		clause, args, skip = c.makeClauseString(operand, val)
-	case int, int32, int64, float32, float64:
+	case bool:
+		clause, args, skip = c.makeClauseBool(whereClauseFmt, operand, val)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
 		clause, args, skip = c.makeClause(whereClauseFmt, operand, val)
 	default:
 	}
@@ -124,6 +404,10 @@ func (c *cursor) makeClauseTimeType() (clause string, args []interface{}, skip b
 		clause, args, skip = c.makeClauseTime(whereClauseFmt, operand, val)
 	case sql.NullTime:
 		clause, args, skip = c.makeClauseNullTime(whereClauseFmt, operand, val)
+	case time.Duration:
+		clause, args, skip = c.makeClauseDuration(whereClauseFmt, operand, val)
+	case RelativeTime:
+		clause, args, skip = c.makeClauseRelativeTime(whereClauseFmt, operand, val)
 	default:
 	}
 
@@ -134,8 +418,11 @@ func (c *cursor) makeClauseArrayType() (clause string, args []interface{}, skip
 	skip = true
 
 	switch val := c.field.Interface().(type) {
-	case []string, []int, []int32, []int64, []float32, []float64:
+	case []string, []bool, []int, []int8, []int16, []int32, []int64,
+		[]uint, []uint16, []uint32, []uint64, []float32, []float64:
 		clause, args, skip = c.makeClauseMulti(val)
+	case []time.Duration:
+		clause, args, skip = c.makeClauseMultiDuration(val)
 	default:
 	}
 
@@ -164,7 +451,7 @@ func (c *cursor) makeClauseSqlNullType() (clause string, args []interface{}, ski
 }
 
 func (c *cursor) makeClause(layout, operand string, val interface{}) (clause string, args []interface{}, skip bool) {
-	clause = fmt.Sprintf(layout, c.db, operand)
+	clause = fmt.Sprintf(layout, c.Column(), operand)
 	args = append(args, val)
 
 	return
@@ -176,11 +463,21 @@ func (c *cursor) makeClauseString(operand string, val string) (clause string, ar
 		return
 	}
 
-	if c.field.String() == "" {
+	if val == "" && !c.ptr {
 		skip = true
 		return
 	}
 
+	if c.IsSearch() {
+		clause = " AND " + c.SearchExpr()
+		args = append(args, val)
+		return
+	}
+
+	if c.IsStringMatch() {
+		return c.makeClauseStringMatch(val)
+	}
+
 	if operand == "=" {
 		operand = "LIKE"
 	}
@@ -188,28 +485,119 @@ func (c *cursor) makeClauseString(operand string, val string) (clause string, ar
 	return c.makeClause(whereClauseFmt, operand, val)
 }
 
+// makeClauseStringMatch render the regex and case-insensitive match per dialect.
+//
+//	__regex:  MySQL: col REGEXP ?,              Postgres: col ~ ?
+//	__iregex: MySQL: REGEXP_LIKE(col, ?, 'i'), Postgres: col ~* ?
+//	__iexact: MySQL: LOWER(col) = LOWER(?),    Postgres: col ILIKE ?
+func (c *cursor) makeClauseStringMatch(val string) (clause string, args []interface{}, skip bool) {
+	col := c.Column()
+
+	switch op := c.op; {
+	case op == "iregex" && c.qb.dialect == Postgres:
+		clause = fmt.Sprintf(" AND %s ~* ?", col)
+	case op == "iregex":
+		clause = fmt.Sprintf(" AND REGEXP_LIKE(%s, ?, 'i')", col)
+	case op == "regex" && c.qb.dialect == Postgres:
+		clause = fmt.Sprintf(" AND %s ~ ?", col)
+	case op == "regex":
+		clause = fmt.Sprintf(" AND %s REGEXP ?", col)
+	case c.qb.dialect == Postgres:
+		// escape the LIKE wildcards, so ILIKE is an exact match
+		clause = fmt.Sprintf(" AND %s ILIKE ?", col)
+		val = likeEscaper.Replace(val)
+	default:
+		clause = fmt.Sprintf(" AND LOWER(%s) = LOWER(?)", col)
+	}
+
+	args = append(args, val)
+
+	return
+}
+
 func (c *cursor) makeClauseTime(layout, operand string, val time.Time) (clause string, args []interface{}, skip bool) {
 	if val.IsZero() {
 		skip = true
 		return
 	}
 
+	return c.makeClauseTimeValue(layout, operand, val)
+}
+
+// makeClauseTimeValue normalize the time to the builder location, and expand the truncated time to a range.
+func (c *cursor) makeClauseTimeValue(layout, operand string, val time.Time) (clause string, args []interface{}, skip bool) {
+	trunc, err := c.TruncFunc()
+	if err != nil {
+		c.err = err
+		skip = true
+		return
+	}
+
+	if trunc != nil {
+		start, next := trunc(val)
+		return c.makeClauseTimeRange(operand, start, next)
+	}
+
+	return c.makeClause(layout, operand, c.qb.normalizeTime(val))
+}
+
+// makeClauseBool skip false, use *bool or sql.NullBool to filter by false.
+func (c *cursor) makeClauseBool(layout, operand string, val bool) (clause string, args []interface{}, skip bool) {
+	if !val && !c.ptr {
+		skip = true
+		return
+	}
+
 	return c.makeClause(layout, operand, val)
 }
 
+// makeClauseDuration render the duration as integer in the unit of tag:"unit".
+func (c *cursor) makeClauseDuration(layout, operand string, val time.Duration) (clause string, args []interface{}, skip bool) {
+	unit, err := c.DurationUnit()
+	if err != nil {
+		c.err = err
+		skip = true
+		return
+	}
+
+	return c.makeClause(layout, operand, int64(val/unit))
+}
+
 func (c *cursor) makeClauseNullTime(layout, operand string, val sql.NullTime) (clause string, args []interface{}, skip bool) {
 	if !val.Valid {
 		skip = true
 		return
 	}
 
-	return c.makeClause(layout, operand, val.Time)
+	return c.makeClauseTimeValue(layout, operand, val.Time)
 }
 
 func (c *cursor) makeClauseMulti(val interface{}) (clause string, args []interface{}, skip bool) {
+	switch op := c.op; {
+	case op == "like":
+		return c.makeClauseMultiLike(val)
+	case op == "overlap", op == "contains_all":
+		return c.makeClauseMultiJson(val)
+	case op == "any" && c.qb.dialect == Postgres:
+		if reflect.ValueOf(val).Len() < 1 {
+			skip = true
+			return
+		}
+		clause = fmt.Sprintf(" AND %s = ANY(?)", c.Column())
+		args = append(args, c.qb.bindArray(val))
+		return
+	}
+
+	if c.InListStrategy() != InListExpand && reflect.ValueOf(val).Len() > 0 {
+		return c.makeClauseInList(val)
+	}
+
 	operandMulti := c.GetOperandMulti()
-	tempQuery := fmt.Sprintf(whereClauseMultiFmt, c.db, operandMulti)
-	tempQuery, tempArgs, _ := sqlx.In(tempQuery, val)
+	tempQuery := fmt.Sprintf(whereClauseMultiFmt, c.Column(), operandMulti)
+	tempQuery, tempArgs, err := sqlx.In(tempQuery, val)
+	if err != nil && reflect.ValueOf(val).Len() > 0 {
+		c.err = err
+	}
 	clause = tempQuery
 	if len(tempArgs) < 1 {
 		skip = true
@@ -219,6 +607,80 @@ func (c *cursor) makeClauseMulti(val interface{}) (clause string, args []interfa
 	return
 }
 
+// makeClauseMultiDuration render the durations as integers in the unit of tag:"unit".
+func (c *cursor) makeClauseMultiDuration(val []time.Duration) (clause string, args []interface{}, skip bool) {
+	unit, err := c.DurationUnit()
+	if err != nil {
+		c.err = err
+		skip = true
+		return
+	}
+
+	ints := make([]int64, len(val))
+	for i, d := range val {
+		ints[i] = int64(d / unit)
+	}
+
+	return c.makeClauseMulti(ints)
+}
+
+// makeClauseMultiLike match any of the LIKE patterns, e.g: (name LIKE ? OR name LIKE ?)
+func (c *cursor) makeClauseMultiLike(val interface{}) (clause string, args []interface{}, skip bool) {
+	patterns, _ := val.([]string)
+	if len(patterns) < 1 {
+		skip = true
+		return
+	}
+
+	likes := make([]string, len(patterns))
+	for i, p := range patterns {
+		likes[i] = c.Column() + " LIKE ?"
+		args = append(args, p)
+	}
+	clause = " AND (" + strings.Join(likes, " OR ") + ")"
+
+	return
+}
+
+// makeClauseMultiJson match the JSON array column, the values are bound as a JSON array.
+//
+//	__overlap:      MySQL: JSON_OVERLAPS(col, ?), Postgres: EXISTS (SELECT 1 FROM jsonb_array_elements(col) AS e WHERE ?::jsonb @> e)
+//	__contains_all: MySQL: JSON_CONTAINS(col, ?), Postgres: col @> ?::jsonb
+func (c *cursor) makeClauseMultiJson(val interface{}) (clause string, args []interface{}, skip bool) {
+	if reflect.ValueOf(val).Len() < 1 {
+		skip = true
+		return
+	}
+
+	b, err := jsonMarshal(val)
+	if err != nil {
+		c.err = err
+		skip = true
+		return
+	}
+
+	col := c.Column()
+	switch overlap := c.op == "overlap"; {
+	case overlap && c.qb.dialect == Postgres:
+		clause = fmt.Sprintf(" AND EXISTS (SELECT 1 FROM jsonb_array_elements(%s) AS e WHERE ?::jsonb @> e)", col)
+	case overlap:
+		clause = fmt.Sprintf(" AND JSON_OVERLAPS(%s, ?)", col)
+	case c.qb.dialect == Postgres:
+		clause = fmt.Sprintf(" AND %s @> ?::jsonb", col)
+	default:
+		clause = fmt.Sprintf(" AND JSON_CONTAINS(%s, ?)", col)
+	}
+	args = append(args, b)
+
+	return
+}
+
+// jsonMarshal return the JSON string of val.
+func jsonMarshal(val interface{}) (string, error) {
+	b, err := json.Marshal(val)
+	return string(b), err
+}
+
 func (c *cursor) makeClauseNullString(layout, operand string, val sql.NullString) (clause string, args []interface{}, skip bool) {
 	if !val.Valid {
 		skip = true
@@ -260,7 +722,7 @@ func (c *cursor) makeClauseNullBool(layout, operand string, val sql.NullBool) (c
 		skip = true
 		return
 	}
-	clause = fmt.Sprintf(layout, c.db, operand)
+	clause = fmt.Sprintf(layout, c.Column(), operand)
 	args = append(args, val.Bool)
 
 	return
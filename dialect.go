@@ -0,0 +1,41 @@
+package qbuilder
+
+import "github.com/jmoiron/sqlx"
+
+// Dialect of the generated sql, default is MySQL.
+//
+// The generated clause always use `?` as the placeholder, use Rebind to convert it for Postgres.
+type Dialect int
+
+const (
+	MySQL Dialect = iota
+	Postgres
+)
+
+// WithDialect set the sql dialect.
+func WithDialect(d Dialect) Option {
+	return func(qb *queryBuilder) {
+		qb.dialect = d
+	}
+}
+
+// Rebind convert `?` placeholders to the dialect bindvar, e.g: $1, $2 for Postgres.
+func (d Dialect) Rebind(query string) string {
+	switch d {
+	case Postgres:
+		return sqlx.Rebind(sqlx.DOLLAR, query)
+	default:
+		return query
+	}
+}
+
+func (d Dialect) String() string {
+	switch d {
+	case MySQL:
+		return "mysql"
+	case Postgres:
+		return "postgres"
+	default:
+		return "unknown"
+	}
+}
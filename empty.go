@@ -0,0 +1,59 @@
+package qbuilder
+
+import "fmt"
+
+// EmptySlicePolicy is how a non-nil empty slice filter is rendered, a nil slice is always skipped.
+type EmptySlicePolicy int
+
+const (
+	// EmptySkip skip the filter, e.g: []int64{} => no filter
+	EmptySkip EmptySlicePolicy = iota
+
+	// EmptyNone match nothing, e.g: id IN () => 1=0
+	//
+	// For NOT IN and __contains_all an empty slice match every row, so the filter is skipped.
+	EmptyNone
+
+	// EmptyError return ErrEmptySlice
+	EmptyError
+)
+
+var emptySlicePolicies = map[string]EmptySlicePolicy{
+	"skip":  EmptySkip,
+	"none":  EmptyNone,
+	"error": EmptyError,
+}
+
+// WithEmptySlicePolicy set the policy of non-nil empty slices, default is EmptySkip.
+//
+// It can also be set per field with tag:"empty", e.g: `param:"shop_id" db:"shop_id" empty:"none"`
+func WithEmptySlicePolicy(policy EmptySlicePolicy) Option {
+	return func(qb *queryBuilder) {
+		qb.emptySlicePolicy = policy
+	}
+}
+
+// IsEmptySlice report whether the field is a non-nil empty slice.
+func (c *cursor) IsEmptySlice() bool {
+	return c.IsSlice() && !c.field.IsNil() && c.field.Len() == 0
+}
+
+// EmptySlicePolicy return the policy from tag:"empty", default is the builder policy.
+func (c *cursor) EmptySlicePolicy() (EmptySlicePolicy, error) {
+	tag := c.tag.Get("empty")
+	if tag == "" {
+		return c.qb.emptySlicePolicy, nil
+	}
+
+	policy, ok := emptySlicePolicies[tag]
+	if !ok {
+		return EmptySkip, fmt.Errorf("unknown empty policy %q, should be skip, none or error", tag)
+	}
+
+	return policy, nil
+}
+
+// IsExclusive report whether an empty slice match every row, e.g: NOT IN ()
+func (c *cursor) IsExclusive() bool {
+	return c.op == "nin" || c.op == "contains_all"
+}
@@ -0,0 +1,94 @@
+package qbuilder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamEmptySlice struct {
+	ShopIDs   []int64  `param:"shop_id" db:"shop_id" empty:"none"`
+	StatusNIN []string `param:"status__nin" db:"status" empty:"none"`
+	Tags      []string `param:"tags__overlap" db:"tags"`
+}
+
+type ParamEmptySliceError struct {
+	ShopIDs []int64 `param:"shop_id" db:"shop_id" empty:"error"`
+}
+
+type ParamInvalidEmptyTag struct {
+	ShopIDs []int64 `param:"shop_id" db:"shop_id" empty:"nothing"`
+}
+
+func Test_QBuilder_EmptySlice(t *testing.T) {
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		param     interface{}
+		expClause string
+		expErr    error
+	}{
+		{
+			desc:      "nil slices are skipped",
+			param:     &ParamEmptySlice{},
+			expClause: " WHERE 1=1 LIMIT 0, 10",
+		},
+		{
+			desc:      "none policy match nothing",
+			param:     &ParamEmptySlice{ShopIDs: []int64{}, StatusNIN: []string{}},
+			expClause: " WHERE 1=1 AND 1=0 LIMIT 0, 10",
+		},
+		{
+			desc:      "default policy is skip",
+			param:     &ParamEmptySlice{Tags: []string{}},
+			expClause: " WHERE 1=1 LIMIT 0, 10",
+		},
+		{
+			desc:      "builder policy",
+			opt:       []Option{WithEmptySlicePolicy(EmptyNone)},
+			param:     &ParamEmptySlice{Tags: []string{}},
+			expClause: " WHERE 1=1 AND 1=0 LIMIT 0, 10",
+		},
+		{
+			desc:   "error policy",
+			param:  &ParamEmptySliceError{ShopIDs: []int64{}},
+			expErr: ErrEmptySlice,
+		},
+		{
+			desc:   "builder error policy",
+			opt:    []Option{WithEmptySlicePolicy(EmptyError)},
+			param:  &ParamArr{Ints: []int{}},
+			expErr: ErrEmptySlice,
+		},
+		{
+			desc:      "tag override builder policy",
+			opt:       []Option{WithEmptySlicePolicy(EmptyError)},
+			param:     &ParamEmptySlice{ShopIDs: []int64{}},
+			expClause: " WHERE 1=1 AND 1=0 LIMIT 0, 10",
+		},
+		{
+			desc:   "invalid tag",
+			param:  &ParamInvalidEmptyTag{ShopIDs: []int64{}},
+			expErr: ErrInvalidParam,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(tc.opt...).Build(tc.param)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Nil(t, args)
+		})
+	}
+
+	t.Run("invalid tag on construction", func(t *testing.T) {
+		_, err := For[ParamInvalidEmptyTag]()
+		assert.ErrorIs(t, err, ErrInvalidParam)
+	})
+}
@@ -0,0 +1,77 @@
+package qbuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidParam is returned when the param is not a non-nil pointer to struct, or a field tag is invalid.
+	ErrInvalidParam = errors.New("qbuilder: invalid param")
+
+	// ErrUnsupportedType is returned when a tagged field type cannot be rendered.
+	ErrUnsupportedType = errors.New("qbuilder: unsupported field type")
+
+	// ErrInvalidOperator is returned when the operator suffix of tag:"param" is invalid for the field.
+	ErrInvalidOperator = errors.New("qbuilder: invalid operator")
+
+	// ErrSortFieldNotAllowed is returned when the sort field is not in the sortable fields.
+	ErrSortFieldNotAllowed = errors.New("qbuilder: sort field is not allowed")
+
+	// ErrLimitExceeded is returned when the page or limit exceed the maximum.
+	ErrLimitExceeded = errors.New("qbuilder: limit exceeded")
+
+	// ErrTooManyValues is returned when the IN list cannot be rendered, see WithInListThreshold.
+	ErrTooManyValues = errors.New("qbuilder: too many values")
+
+	// ErrEmptySlice is returned when a slice filter is empty and its policy is EmptyError.
+	ErrEmptySlice = errors.New("qbuilder: empty slice")
+
+	// ErrMissingParam is returned when a field tagged param:"name,required" is the zero value.
+	ErrMissingParam = errors.New("qbuilder: missing required param")
+
+	// ErrEmptyWhere is returned by BuildUpdate and BuildDelete when there is no where clause.
+	ErrEmptyWhere = errors.New("qbuilder: empty where clause is not allowed")
+)
+
+// FieldError is the error of a param struct field, Err is one of the sentinel errors.
+//
+// e.g:
+//
+//	var fe *qbuilder.FieldError
+//	if errors.As(err, &fe) {
+//		// 400 Bad Request: fe.Param, fe.Reason
+//	}
+type FieldError struct {
+	Field  string // struct field name, e.g: CreatedAtGTE
+	Param  string // tag:"param", e.g: created_at__gte
+	Reason string
+	Err    error
+}
+
+func newFieldError(err error, field, param, reason string, args ...interface{}) *FieldError {
+	return &FieldError{
+		Field:  field,
+		Param:  param,
+		Reason: fmt.Sprintf(reason, args...),
+		Err:    err,
+	}
+}
+
+func (e *FieldError) Error() string {
+	msg := fmt.Sprintf("%v", e.Err)
+	if e.Field != "" {
+		msg += ": field " + e.Field
+	}
+	if e.Param != "" {
+		msg += fmt.Sprintf(" (param %q)", e.Param)
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
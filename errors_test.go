@@ -0,0 +1,109 @@
+package qbuilder
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamSortable struct {
+	ShortBy []string `param:"short_by" sortable:"name,created_at"`
+}
+
+type ParamUnexported struct {
+	name string `param:"name" db:"name"`
+}
+
+func Test_QBuilder_Errors(t *testing.T) {
+	testCase := []struct {
+		desc     string
+		qb       *queryBuilder
+		param    interface{}
+		expErr   error
+		expField *FieldError
+	}{
+		{
+			desc:   "not pointer",
+			qb:     New(),
+			param:  ParamNull{},
+			expErr: ErrInvalidParam,
+		},
+		{
+			desc:   "nil pointer",
+			qb:     New(),
+			param:  (*ParamNull)(nil),
+			expErr: ErrInvalidParam,
+		},
+		{
+			desc:     "unsupported type",
+			qb:       New(),
+			param:    &ParamUnsupported{Map: map[string]string{"a": "b"}},
+			expErr:   ErrUnsupportedType,
+			expField: &FieldError{Field: "Map", Param: "map"},
+		},
+		{
+			desc:     "invalid aggregate",
+			qb:       New(),
+			param:    &ParamInvalidAggregate{},
+			expErr:   ErrInvalidParam,
+			expField: &FieldError{Field: "Total", Param: "total"},
+		},
+		{
+			desc:     "unexported field",
+			qb:       New(),
+			param:    &ParamUnexported{},
+			expErr:   ErrInvalidParam,
+			expField: &FieldError{Field: "name", Param: "name"},
+		},
+		{
+			desc:     "sort field not allowed by tag",
+			qb:       New(),
+			param:    &ParamSortable{ShortBy: []string{"-created_at", "password"}},
+			expErr:   ErrSortFieldNotAllowed,
+			expField: &FieldError{Field: "ShortBy", Param: "short_by"},
+		},
+		{
+			desc:     "sort field not allowed by option",
+			qb:       New(WithSortableFields("status")),
+			param:    &ParamPaginationInt64{ShortBy: []string{"-created_at"}},
+			expErr:   ErrSortFieldNotAllowed,
+			expField: &FieldError{Field: "ShortBy", Param: "short_by"},
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			_, _, err := tc.qb.Build(tc.param)
+			assert.True(t, errors.Is(err, tc.expErr), err)
+
+			var fe *FieldError
+			if tc.expField == nil {
+				assert.False(t, errors.As(err, &fe))
+				return
+			}
+			assert.True(t, errors.As(err, &fe))
+			assert.Equal(t, tc.expField.Field, fe.Field)
+			assert.Equal(t, tc.expField.Param, fe.Param)
+		})
+	}
+
+	t.Run("sort field allowed", func(t *testing.T) {
+		param := ParamSortable{ShortBy: []string{"-created_at", "name"}}
+		clause, _, err := New().Build(&param)
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 ORDER BY created_at DESC, name ASC LIMIT 0, 10", clause)
+	})
+
+	t.Run("empty where", func(t *testing.T) {
+		_, _, err := New().BuildDelete(&ParamNull{})
+		assert.True(t, errors.Is(err, ErrEmptyWhere))
+	})
+}
+
+func Test_FieldError(t *testing.T) {
+	err := newFieldError(ErrUnsupportedType, "Map", "map", "%s", "map[string]string")
+	assert.Equal(t, `qbuilder: unsupported field type: field Map (param "map"): map[string]string`, err.Error())
+	assert.Equal(t, ErrUnsupportedType, errors.Unwrap(err))
+}
@@ -47,34 +47,18 @@ func main() {
 		ID: sql.NullInt64{Int64: 1, Valid: true},
 	}
 
-	qb := qbuilder.New()
-	wc, args, err := qb.Build(&param)
-	if err != nil {
-		fmt.Println("failed query builder", err)
-		return
-	}
-
-	rows, err := db.QueryContext(context.Background(), query+wc, args...)
+	products, err := qbuilder.Find[Product](context.Background(), db, query, &param)
 	if err != nil {
 		fmt.Println("failed query", err)
 		return
 	}
 
-	var products []Product
-	for rows.Next() {
-		var p Product
-		if err = rows.Scan(&p.ID, &p.Name); err != nil {
-			fmt.Println("failed scan", err)
-		}
-		products = append(products, p)
-	}
-
 	fmt.Printf("products: %+v\n", products)
 }
 
 type Product struct {
-	ID   int64
-	Name string
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
 }
 
 type ProductParam struct {
@@ -0,0 +1,138 @@
+package qbuilder
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Queryer is satisfied by *sql.DB, *sql.Tx, *sqlx.DB and *sqlx.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryerx is satisfied by *sqlx.DB and *sqlx.Tx, its mapper and bindvar are used when available.
+type queryerx interface {
+	QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error)
+	Rebind(query string) string
+}
+
+// WithWhereClause add custom where clause, same as AddWhereClause.
+func WithWhereClause(wc string, args ...interface{}) Option {
+	return func(qb *queryBuilder) {
+		qb.AddWhereClause(wc, args...)
+	}
+}
+
+// WithConcurrentCount run the count query of FindPage concurrently with the data query.
+//
+// Do not use it with *sql.Tx or *sqlx.Tx, a transaction cannot run queries concurrently.
+func WithConcurrentCount() Option {
+	return func(qb *queryBuilder) {
+		qb.concurrentCount = true
+	}
+}
+
+// Find run baseQuery filtered by param and scan the rows into []T using the db tags.
+//
+// e.g: products, err := qbuilder.Find[Product](ctx, db, "SELECT id, name FROM product", &param)
+func Find[T any](ctx context.Context, db Queryer, baseQuery string, param interface{}, opts ...Option) ([]T, error) {
+	q := New(opts...)
+
+	clause, args, err := q.Build(param)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryRows[T](ctx, db, q.rebind(db, baseQuery+clause), args)
+}
+
+// FindPage run baseQuery filtered by param and its count query, and return the page of T.
+func FindPage[T any](ctx context.Context, db Queryer, baseQuery string, param interface{}, opts ...Option) (Page[T], error) {
+	q := New(opts...)
+
+	clause, args, err := q.Build(param)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	countQuery, countArgs, err := q.BuildCountQuery(baseQuery)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	var (
+		items    []T
+		total    int64
+		countErr error
+		done     = make(chan struct{})
+	)
+
+	countFn := func() {
+		defer close(done)
+		countErr = queryCount(ctx, db, q.rebind(db, countQuery), countArgs, &total)
+	}
+
+	if q.concurrentCount {
+		go countFn()
+	} else {
+		countFn()
+	}
+
+	items, err = queryRows[T](ctx, db, q.rebind(db, baseQuery+clause), args)
+	<-done
+	if err != nil {
+		return Page[T]{}, err
+	}
+	if countErr != nil {
+		return Page[T]{}, countErr
+	}
+
+	return PageOf(q, items, &total), nil
+}
+
+func (q *queryBuilder) rebind(db Queryer, query string) string {
+	if dbx, ok := db.(queryerx); ok {
+		return dbx.Rebind(query)
+	}
+	return q.dialect.Rebind(query)
+}
+
+func queryRows[T any](ctx context.Context, db Queryer, query string, args []interface{}) (items []T, err error) {
+	if dbx, ok := db.(queryerx); ok {
+		rows, err := dbx.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		err = sqlx.StructScan(rows, &items)
+		return items, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	err = sqlx.StructScan(rows, &items)
+	return items, err
+}
+
+func queryCount(ctx context.Context, db Queryer, query string, args []interface{}, total *int64) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err = rows.Scan(total); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
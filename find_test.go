@@ -0,0 +1,127 @@
+package qbuilder
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver return the product rows for data query and the total for count query.
+type fakeDriver struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("qbuilder_fake", fake)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+func (d *fakeDriver) reset() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	queries := d.queries
+	d.queries = nil
+	return queries
+}
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *fakeConn) Commit() error                             { return nil }
+func (c *fakeConn) Rollback() error                           { return nil }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.mu.Lock()
+	c.d.queries = append(c.d.queries, query)
+	c.d.mu.Unlock()
+
+	if strings.HasPrefix(query, "SELECT COUNT(*)") {
+		return &fakeRows{cols: []string{"count"}, rows: [][]driver.Value{{int64(3)}}}, nil
+	}
+	return &fakeRows{cols: []string{"id", "name"}, rows: [][]driver.Value{{int64(1), "foo"}, {int64(2), "bar"}}}, nil
+}
+
+type fakeRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type FindProduct struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+func Test_Find(t *testing.T) {
+	db, err := sql.Open("qbuilder_fake", "")
+	assert.Nil(t, err)
+	defer db.Close()
+	fake.reset()
+
+	param := ParamNull{NullString: sql.NullString{Valid: true, String: "foo"}}
+	products, err := Find[FindProduct](context.Background(), db, "SELECT id, name FROM product", &param)
+	assert.Nil(t, err)
+	assert.Equal(t, []FindProduct{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}, products)
+	assert.Equal(t, []string{"SELECT id, name FROM product WHERE 1=1 AND nullstring LIKE ? LIMIT 0, 10"}, fake.reset())
+}
+
+func Test_FindPage(t *testing.T) {
+	db, err := sql.Open("qbuilder_fake", "")
+	assert.Nil(t, err)
+	defer db.Close()
+	fake.reset()
+
+	t.Run("sql.DB", func(t *testing.T) {
+		param := ParamPaginationInt64{Page: 1, Limit: 2}
+		page, err := FindPage[FindProduct](context.Background(), db, "SELECT id, name FROM product", &param, WithConcurrentCount())
+		assert.Nil(t, err)
+		assert.Equal(t, []FindProduct{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}, page.Items)
+		assert.Equal(t, int64(3), *page.Total)
+		assert.Equal(t, int64(2), *page.TotalPages)
+		assert.True(t, page.HasNext)
+		assert.ElementsMatch(t, []string{
+			"SELECT id, name FROM product WHERE 1=1 LIMIT 0, 2",
+			"SELECT COUNT(*) FROM (SELECT id, name FROM product WHERE 1=1) AS qbuilder_count",
+		}, fake.reset())
+	})
+
+	t.Run("sqlx.Tx", func(t *testing.T) {
+		tx, err := sqlx.NewDb(db, "postgres").Beginx()
+		assert.Nil(t, err)
+		defer tx.Rollback()
+
+		param := ParamNull{NullInt64: sql.NullInt64{Valid: true, Int64: 1}}
+		page, err := FindPage[FindProduct](context.Background(), tx, "SELECT id, name FROM product", &param)
+		assert.Nil(t, err)
+		assert.Len(t, page.Items, 2)
+		assert.False(t, page.HasNext)
+		assert.Equal(t, []string{
+			"SELECT COUNT(*) FROM (SELECT id, name FROM product WHERE 1=1 AND nullint64 = $1) AS qbuilder_count",
+			"SELECT id, name FROM product WHERE 1=1 AND nullint64 = $1 LIMIT 0, 10",
+		}, fake.reset())
+	})
+}
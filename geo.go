@@ -0,0 +1,150 @@
+package qbuilder
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Point is a geographic coordinate in degrees, the column is a POINT(lng lat).
+//
+// As a param it doesn't filter, it's the origin of the distance sort key, e.g:
+//
+//	Origin qbuilder.Point `param:"origin" db:"location" sort_key:"distance"` => sort_by=distance
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Radius filter the rows within Meters of Center, e.g: `param:"near" db:"location"`
+//
+//	MySQL:    ST_Distance_Sphere(location, POINT(?, ?)) <= ?
+//	Postgres: ST_DWithin(location::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)
+type Radius struct {
+	Center Point   `json:"center"`
+	Meters float64 `json:"meters"`
+}
+
+// BBox filter the rows inside the bounding box, e.g: `param:"bbox" db:"location"`
+//
+//	MySQL:    MBRContains(ST_MakeEnvelope(POINT(?, ?), POINT(?, ?)), location)
+//	Postgres: ST_Contains(ST_MakeEnvelope(?, ?, ?, ?, 4326), location::geometry)
+type BBox struct {
+	Min Point `json:"min"` // south-west
+	Max Point `json:"max"` // north-east
+}
+
+func (p Point) validate() error {
+	if p.Lat < -90 || p.Lat > 90 || p.Lng < -180 || p.Lng > 180 {
+		return fmt.Errorf("invalid point (%v, %v)", p.Lat, p.Lng)
+	}
+	return nil
+}
+
+// IsGeo report whether the field is Point, Radius or BBox, or a pointer to them.
+func (c *cursor) IsGeo() bool {
+	t := c.field.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch reflect.Zero(t).Interface().(type) {
+	case Point, Radius, BBox:
+		return true
+	default:
+		return false
+	}
+}
+
+// DistanceExpr return the distance in meters between the column and p.
+//
+//	MySQL:    ST_Distance_Sphere(col, POINT(?, ?))
+//	Postgres: ST_Distance(col::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography)
+func (c *cursor) DistanceExpr(p Point) (string, []interface{}) {
+	if c.qb.dialect == Postgres {
+		return fmt.Sprintf("ST_Distance(%s::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography)", c.Column()), []interface{}{p.Lng, p.Lat}
+	}
+	return fmt.Sprintf("ST_Distance_Sphere(%s, POINT(?, ?))", c.Column()), []interface{}{p.Lng, p.Lat}
+}
+
+// geoOrigin return the origin of the distance sort key, ok is false if the field is not set.
+func (c *cursor) geoOrigin() (p Point, ok bool) {
+	field := c.field
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return Point{}, false
+		}
+		field = field.Elem()
+	}
+
+	switch val := field.Interface().(type) {
+	case Point:
+		return val, c.field.Kind() == reflect.Ptr || val != Point{}
+	case Radius:
+		return val.Center, val.Meters > 0
+	default:
+		return Point{}, false
+	}
+}
+
+func (c *cursor) makeClauseGeoType() (clause string, args []interface{}, skip bool) {
+	skip = true
+
+	switch val := c.field.Interface().(type) {
+	case Point:
+		c.err = val.validate()
+	case Radius:
+		clause, args, skip = c.makeClauseRadius(val)
+	case BBox:
+		clause, args, skip = c.makeClauseBBox(val)
+	default:
+	}
+
+	return
+}
+
+func (c *cursor) makeClauseRadius(val Radius) (clause string, args []interface{}, skip bool) {
+	if val.Meters <= 0 {
+		skip = true
+		return
+	}
+
+	if c.err = val.Center.validate(); c.err != nil {
+		skip = true
+		return
+	}
+
+	if c.qb.dialect == Postgres {
+		clause = fmt.Sprintf(" AND ST_DWithin(%s::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)", c.Column())
+		args = append(args, val.Center.Lng, val.Center.Lat, val.Meters)
+		return
+	}
+
+	expr, args := c.DistanceExpr(val.Center)
+	clause = " AND " + expr + " <= ?"
+	args = append(args, val.Meters)
+
+	return
+}
+
+func (c *cursor) makeClauseBBox(val BBox) (clause string, args []interface{}, skip bool) {
+	if val == (BBox{}) && !c.ptr {
+		skip = true
+		return
+	}
+
+	for _, p := range []Point{val.Min, val.Max} {
+		if c.err = p.validate(); c.err != nil {
+			skip = true
+			return
+		}
+	}
+
+	if c.qb.dialect == Postgres {
+		clause = fmt.Sprintf(" AND ST_Contains(ST_MakeEnvelope(?, ?, ?, ?, 4326), %s::geometry)", c.Column())
+	} else {
+		clause = fmt.Sprintf(" AND MBRContains(ST_MakeEnvelope(POINT(?, ?), POINT(?, ?)), %s)", c.Column())
+	}
+	args = append(args, val.Min.Lng, val.Min.Lat, val.Max.Lng, val.Max.Lat)
+
+	return
+}
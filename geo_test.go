@@ -0,0 +1,87 @@
+package qbuilder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamGeo struct {
+	Near Radius `param:"near" db:"location" sort_key:"distance"`
+	BBox *BBox  `param:"bbox" db:"location"`
+	Sort string `param:"sort"`
+}
+
+type ParamGeoOrigin struct {
+	Origin Point  `param:"origin" db:"location" sort_key:"distance"`
+	Sort   string `param:"sort"`
+}
+
+type ParamInvalidGeoOperator struct {
+	Near Radius `param:"near__gt" db:"location"`
+}
+
+func Test_QBuilder_Geo(t *testing.T) {
+	jakarta := Point{Lat: -6.2, Lng: 106.8}
+	bbox := &BBox{Min: Point{Lat: -6.4, Lng: 106.6}, Max: Point{Lat: -6.1, Lng: 107}}
+
+	testCase := []struct {
+		desc      string
+		dialect   Dialect
+		param     interface{}
+		expClause string
+		expArgs   []interface{}
+		expErr    error
+	}{
+		{
+			desc:      "mysql",
+			param:     &ParamGeo{Near: Radius{Center: jakarta, Meters: 5000}, BBox: bbox, Sort: "distance"},
+			expClause: " WHERE 1=1 AND ST_Distance_Sphere(location, POINT(?, ?)) <= ? AND MBRContains(ST_MakeEnvelope(POINT(?, ?), POINT(?, ?)), location) ORDER BY ST_Distance_Sphere(location, POINT(?, ?)) ASC LIMIT 0, 10",
+			expArgs:   []interface{}{106.8, -6.2, float64(5000), 106.6, -6.4, float64(107), -6.1, 106.8, -6.2},
+		},
+		{
+			desc:    "postgres",
+			dialect: Postgres,
+			param:   &ParamGeo{Near: Radius{Center: jakarta, Meters: 5000}, BBox: bbox, Sort: "distance"},
+			expClause: " WHERE 1=1 AND ST_DWithin(location::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)" +
+				" AND ST_Contains(ST_MakeEnvelope(?, ?, ?, ?, 4326), location::geometry)" +
+				" ORDER BY ST_Distance(location::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography) ASC LIMIT 10 OFFSET 0",
+			expArgs: []interface{}{106.8, -6.2, float64(5000), 106.6, -6.4, float64(107), -6.1, 106.8, -6.2},
+		},
+		{
+			desc:      "not set",
+			param:     &ParamGeo{Sort: "distance,id"},
+			expClause: " WHERE 1=1 ORDER BY id ASC LIMIT 0, 10",
+		},
+		{
+			desc:      "sort by distance from the origin",
+			param:     &ParamGeoOrigin{Origin: jakarta, Sort: "distance"},
+			expClause: " WHERE 1=1 ORDER BY ST_Distance_Sphere(location, POINT(?, ?)) ASC LIMIT 0, 10",
+			expArgs:   []interface{}{106.8, -6.2},
+		},
+		{
+			desc:   "invalid point",
+			param:  &ParamGeo{Near: Radius{Center: Point{Lat: 106.8, Lng: -6.2}, Meters: 5000}},
+			expErr: ErrInvalidParam,
+		},
+		{
+			desc:   "operator",
+			param:  &ParamInvalidGeoOperator{},
+			expErr: ErrInvalidOperator,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(WithDialect(tc.dialect)).Build(tc.param)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+}
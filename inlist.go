@@ -0,0 +1,180 @@
+package qbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InListStrategy is how a large IN list is rendered, see WithInListThreshold.
+type InListStrategy int
+
+const (
+	// InListExpand bind every value as a placeholder, e.g: col IN (?, ?, ?)
+	InListExpand InListStrategy = iota
+
+	// InListChunk split the values into OR'ed IN lists of threshold size, e.g: (col IN (?, ?) OR col IN (?))
+	InListChunk
+
+	// InListJSONTable bind the values as a single JSON array parameter (MySQL 8), e.g:
+	// col IN (SELECT v FROM JSON_TABLE(?, '$[*]' COLUMNS (v BIGINT PATH '$')) AS qbuilder_in)
+	// A []bool is bound as 0 and 1 into a TINYINT column.
+	InListJSONTable
+
+	// InListArray bind the values as a single array parameter (Postgres), e.g: col = ANY(?)
+	InListArray
+)
+
+const inListJSONTableFmt = "(SELECT v FROM JSON_TABLE(?, '$[*]' COLUMNS (v %s PATH '$')) AS qbuilder_in)"
+
+// WithInListThreshold render the IN / NOT IN list with the strategy when it has more values than threshold.
+//
+// A list that cannot be rendered (e.g: exceed MySQL's 65,535 placeholders, or JSON_TABLE on Postgres)
+// return ErrTooManyValues.
+func WithInListThreshold(threshold int, strategy InListStrategy) Option {
+	return func(qb *queryBuilder) {
+		qb.inListThreshold = threshold
+		qb.inListStrategy = strategy
+	}
+}
+
+// IsInList report whether the slice field is rendered as IN / NOT IN, custom operators render their template.
+func (c *cursor) IsInList() bool {
+	if !c.IsSlice() {
+		return false
+	}
+	if op, ok := lookupOperator(c.op); !ok || !op.builtin {
+		return false
+	}
+
+	switch op := c.op; {
+	case op == "like", op == "overlap", op == "contains_all",
+		op == "any" && c.qb.dialect == Postgres:
+		return false
+	default:
+		return true
+	}
+}
+
+// InListStrategy return the strategy for the number of values.
+func (c *cursor) InListStrategy() InListStrategy {
+	if c.qb.inListThreshold > 0 && c.field.Len() > c.qb.inListThreshold {
+		return c.qb.inListStrategy
+	}
+	return InListExpand
+}
+
+// CheckInList return the reason if the IN list cannot be rendered.
+func (c *cursor) CheckInList() string {
+	if !c.IsInList() {
+		return ""
+	}
+
+	n := c.field.Len()
+
+	switch strategy := c.InListStrategy(); {
+	case strategy == InListJSONTable && c.qb.dialect != MySQL:
+		return fmt.Sprintf("%d values: JSON_TABLE is only supported by MySQL", n)
+	case strategy == InListArray && c.qb.dialect != Postgres:
+		return fmt.Sprintf("%d values: array parameter is only supported by Postgres", n)
+	case (strategy == InListExpand || strategy == InListChunk) && n > maxPlaceholders:
+		return fmt.Sprintf("%d values exceed the maximum %d placeholders", n, maxPlaceholders)
+	default:
+		return ""
+	}
+}
+
+// makeClauseInList render the IN / NOT IN list with the strategy, the list is not empty.
+func (c *cursor) makeClauseInList(val interface{}) (clause string, args []interface{}, skip bool) {
+	operand := c.GetOperandMulti()
+
+	switch c.InListStrategy() {
+	case InListChunk:
+		return c.makeClauseInListChunk(val, operand)
+	case InListJSONTable:
+		b, err := jsonMarshal(jsonTableValues(val))
+		if err != nil {
+			c.err = err
+			skip = true
+			return
+		}
+		clause = fmt.Sprintf(" AND %s %s "+inListJSONTableFmt, c.Column(), operand, jsonTableType(c.field.Type().Elem()))
+		args = append(args, b)
+	case InListArray:
+		if operand == "IN" {
+			clause = fmt.Sprintf(" AND %s = ANY(?)", c.Column())
+		} else {
+			clause = fmt.Sprintf(" AND %s <> ALL(?)", c.Column())
+		}
+		args = append(args, c.qb.bindArray(val))
+	}
+
+	return
+}
+
+// makeClauseInListChunk e.g: (col IN (?, ?) OR col IN (?)) or (col NOT IN (?, ?) AND col NOT IN (?))
+func (c *cursor) makeClauseInListChunk(val interface{}, operand string) (clause string, args []interface{}, skip bool) {
+	var (
+		v      = reflect.ValueOf(val)
+		size   = c.qb.inListThreshold
+		chunks []string
+	)
+
+	for start := 0; start < v.Len(); start += size {
+		end := start + size
+		if end > v.Len() {
+			end = v.Len()
+		}
+
+		chunk, chunkArgs, err := sqlx.In(fmt.Sprintf("%s %s (?)", c.Column(), operand), v.Slice(start, end).Interface())
+		if err != nil {
+			c.err = err
+			skip = true
+			return
+		}
+		chunks = append(chunks, chunk)
+		args = append(args, chunkArgs...)
+	}
+
+	sep := " OR "
+	if operand != "IN" {
+		sep = " AND "
+	}
+	clause = " AND (" + strings.Join(chunks, sep) + ")"
+
+	return
+}
+
+// jsonTableType return the JSON_TABLE column type of the slice element.
+func jsonTableType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "BIGINT"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "BIGINT UNSIGNED"
+	case reflect.Float32, reflect.Float64:
+		return "DOUBLE"
+	case reflect.Bool:
+		return "TINYINT"
+	default:
+		return "VARCHAR(1024)"
+	}
+}
+
+// jsonTableValues convert []bool into 0 and 1, a JSON true is not comparable with a TINYINT column.
+func jsonTableValues(val interface{}) interface{} {
+	bools, ok := val.([]bool)
+	if !ok {
+		return val
+	}
+
+	ints := make([]int, len(bools))
+	for i, b := range bools {
+		if b {
+			ints[i] = 1
+		}
+	}
+	return ints
+}
@@ -0,0 +1,101 @@
+package qbuilder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamInList struct {
+	IDs       []int64  `param:"id" db:"id"`
+	StatusNIN []string `param:"status__nin" db:"status"`
+}
+
+type ParamInListBool struct {
+	Flags []bool `param:"flag" db:"flag"`
+}
+
+func Test_QBuilder_InList(t *testing.T) {
+	param := ParamInList{
+		IDs:       []int64{1, 2, 3},
+		StatusNIN: []string{"A", "B", "C"},
+	}
+
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		expClause string
+		expArgs   []interface{}
+		expErr    error
+	}{
+		{
+			desc:      "below threshold",
+			opt:       []Option{WithInListThreshold(3, InListChunk)},
+			expClause: " WHERE 1=1 AND id IN (?, ?, ?) AND status NOT IN (?, ?, ?) LIMIT 0, 10",
+			expArgs:   []interface{}{int64(1), int64(2), int64(3), "A", "B", "C"},
+		},
+		{
+			desc:      "chunk",
+			opt:       []Option{WithInListThreshold(2, InListChunk)},
+			expClause: " WHERE 1=1 AND (id IN (?, ?) OR id IN (?)) AND (status NOT IN (?, ?) AND status NOT IN (?)) LIMIT 0, 10",
+			expArgs:   []interface{}{int64(1), int64(2), int64(3), "A", "B", "C"},
+		},
+		{
+			desc:      "json table",
+			opt:       []Option{WithInListThreshold(2, InListJSONTable)},
+			expClause: " WHERE 1=1 AND id IN (SELECT v FROM JSON_TABLE(?, '$[*]' COLUMNS (v BIGINT PATH '$')) AS qbuilder_in) AND status NOT IN (SELECT v FROM JSON_TABLE(?, '$[*]' COLUMNS (v VARCHAR(1024) PATH '$')) AS qbuilder_in) LIMIT 0, 10",
+			expArgs:   []interface{}{"[1,2,3]", `["A","B","C"]`},
+		},
+		{
+			desc:      "postgres array",
+			opt:       []Option{WithDialect(Postgres), WithInListThreshold(2, InListArray)},
+			expClause: " WHERE 1=1 AND id = ANY(?) AND status <> ALL(?) LIMIT 10 OFFSET 0",
+			expArgs:   []interface{}{[]int64{1, 2, 3}, []string{"A", "B", "C"}},
+		},
+		{
+			desc:   "json table is not supported by postgres",
+			opt:    []Option{WithDialect(Postgres), WithInListThreshold(2, InListJSONTable)},
+			expErr: ErrTooManyValues,
+		},
+		{
+			desc:   "array is not supported by mysql",
+			opt:    []Option{WithInListThreshold(2, InListArray)},
+			expErr: ErrTooManyValues,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(tc.opt...).Build(&param)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+
+	t.Run("json table of bool", func(t *testing.T) {
+		param := ParamInListBool{Flags: []bool{true, false, true}}
+
+		clause, args, err := New(WithInListThreshold(2, InListJSONTable)).Build(&param)
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND flag IN (SELECT v FROM JSON_TABLE(?, '$[*]' COLUMNS (v TINYINT PATH '$')) AS qbuilder_in) LIMIT 0, 10", clause)
+		assert.Equal(t, []interface{}{"[1,0,1]"}, args)
+	})
+
+	t.Run("exceed placeholder limit", func(t *testing.T) {
+		param := ParamInList{IDs: make([]int64, 70000)}
+
+		_, _, err := New().Build(&param)
+		assert.ErrorIs(t, err, ErrTooManyValues)
+
+		clause, args, err := New(WithInListThreshold(1000, InListJSONTable)).Build(&param)
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND id IN (SELECT v FROM JSON_TABLE(?, '$[*]' COLUMNS (v BIGINT PATH '$')) AS qbuilder_in) LIMIT 0, 10", clause)
+		assert.Len(t, args, 1)
+	})
+}
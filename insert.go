@@ -0,0 +1,206 @@
+package qbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// maximum number of placeholders in a single MySQL prepared statement
+	maxPlaceholders = 65535
+
+	insertQueryFmt = "INSERT INTO %s (%s) VALUES "
+)
+
+// Statement is a complete query and its arguments.
+type Statement struct {
+	Query string
+	Args  []interface{}
+}
+
+// WithBatchSize set the maximum rows per INSERT statement.
+//
+// The batch size is always capped so a statement never exceed MySQL's 65,535 placeholders.
+func WithBatchSize(n int) Option {
+	return func(qb *queryBuilder) {
+		qb.batchSize = n
+	}
+}
+
+// Upsert update the existing row on duplicate key.
+// If updateCols is empty, all inserted columns except the conflict columns are updated.
+//
+// e.g:
+//
+//	MySQL:    ON DUPLICATE KEY UPDATE name = VALUES(name)
+//	Postgres: ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name
+//
+// The conflict columns are only used by Postgres, MySQL use any unique key.
+// BuildInsert return ErrInvalidParam if they are empty under Postgres.
+func (q *queryBuilder) Upsert(conflictCols []string, updateCols ...string) *queryBuilder {
+	q.upsert = true
+	q.conflictCols = conflictCols
+	q.updateCols = updateCols
+
+	return q
+}
+
+// BuildInsert return the INSERT statements for rows, split into batches.
+// rows is a struct, a pointer to struct or a slice of them, the columns are taken from the db tag.
+//
+// Supported db tag options:
+//
+//	db:"id,auto_increment" => the column is never inserted
+//	db:"name,omitempty"    => zero value is inserted as DEFAULT
+func (q *queryBuilder) BuildInsert(table string, rows interface{}) (stmts []Statement, err error) {
+	if q.upsert && q.dialect == Postgres && len(q.conflictCols) == 0 {
+		return nil, fmt.Errorf("%w: Postgres upsert requires the conflict columns", ErrInvalidParam)
+	}
+
+	values, err := insertRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, fields, omitEmpty := insertColumns(values[0].Type())
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("%w: no column to insert", ErrInvalidParam)
+	}
+
+	batchSize := maxPlaceholders / len(cols)
+	if q.batchSize > 0 && q.batchSize < batchSize {
+		batchSize = q.batchSize
+	}
+
+	prefix := fmt.Sprintf(insertQueryFmt, table, strings.Join(cols, ", "))
+	suffix := q.makeUpsertClause(cols)
+
+	for start := 0; start < len(values); start += batchSize {
+		end := start + batchSize
+		if end > len(values) {
+			end = len(values)
+		}
+
+		var (
+			tuples []string
+			args   []interface{}
+		)
+		for _, row := range values[start:end] {
+			placeholders := make([]string, len(fields))
+			for i, idx := range fields {
+				field := row.Field(idx)
+				if omitEmpty[i] && field.IsZero() {
+					placeholders[i] = "DEFAULT"
+					continue
+				}
+				placeholders[i] = "?"
+				args = append(args, field.Interface())
+			}
+			tuples = append(tuples, "("+strings.Join(placeholders, ", ")+")")
+		}
+
+		stmts = append(stmts, Statement{
+			Query: prefix + strings.Join(tuples, ", ") + suffix,
+			Args:  args,
+		})
+	}
+
+	return stmts, nil
+}
+
+func (q *queryBuilder) makeUpsertClause(cols []string) string {
+	if !q.upsert {
+		return ""
+	}
+
+	updateCols := q.updateCols
+	if len(updateCols) == 0 {
+		for _, col := range cols {
+			if !contains(q.conflictCols, col) {
+				updateCols = append(updateCols, col)
+			}
+		}
+	}
+
+	sets := make([]string, len(updateCols))
+	switch q.dialect {
+	case Postgres:
+		if len(updateCols) == 0 {
+			return fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", strings.Join(q.conflictCols, ", "))
+		}
+		for i, col := range updateCols {
+			sets[i] = fmt.Sprintf("%s = EXCLUDED.%s", col, col)
+		}
+		return fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(q.conflictCols, ", "), strings.Join(sets, ", "))
+	default:
+		if len(updateCols) == 0 {
+			return ""
+		}
+		for i, col := range updateCols {
+			sets[i] = fmt.Sprintf("%s = VALUES(%s)", col, col)
+		}
+		return " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+	}
+}
+
+// insertRows return the struct values of rows.
+func insertRows(rows interface{}) ([]reflect.Value, error) {
+	v := reflect.ValueOf(rows)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	var values []reflect.Value
+	switch v.Kind() {
+	case reflect.Struct:
+		values = append(values, v)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			row := v.Index(i)
+			for row.Kind() == reflect.Ptr && !row.IsNil() {
+				row = row.Elem()
+			}
+			if row.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("%w: rows should be a slice of struct", ErrInvalidParam)
+			}
+			values = append(values, row)
+		}
+	default:
+		return nil, fmt.Errorf("%w: rows should be a struct or a slice of struct", ErrInvalidParam)
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: no rows to insert", ErrInvalidParam)
+	}
+
+	return values, nil
+}
+
+// insertColumns return the columns, its field index and omitempty option of the struct type.
+func insertColumns(t reflect.Type) (cols []string, fields []int, omitEmpty []bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+
+		name, opts := parseTag(t.Field(i).Tag.Get("db"))
+		if name == "" || name == "-" || opts.Has("auto_increment") {
+			continue
+		}
+
+		cols = append(cols, name)
+		fields = append(fields, i)
+		omitEmpty = append(omitEmpty, opts.Has("omitempty"))
+	}
+	return
+}
+
+func contains(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package qbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type InsertProduct struct {
+	ID     int64          `db:"id,auto_increment"`
+	Name   string         `db:"name"`
+	Status string         `db:"status,omitempty"`
+	Note   sql.NullString `db:"note"`
+	Cache  string         `db:"-"`
+}
+
+func Test_QBuilder_BuildInsert(t *testing.T) {
+	rows := []InsertProduct{
+		{ID: 1, Name: "foo", Status: "ACTIVE", Note: sql.NullString{Valid: true, String: "hoho"}},
+		{Name: "bar"},
+		{Name: "baz", Status: "INACTIVE"},
+	}
+
+	testCase := []struct {
+		desc     string
+		qb       *queryBuilder
+		rows     interface{}
+		expStmts []Statement
+	}{
+		{
+			desc: "single row",
+			qb:   New(),
+			rows: &rows[0],
+			expStmts: []Statement{
+				{
+					Query: "INSERT INTO product (name, status, note) VALUES (?, ?, ?)",
+					Args:  []interface{}{"foo", "ACTIVE", sql.NullString{Valid: true, String: "hoho"}},
+				},
+			},
+		},
+		{
+			desc: "multiple rows with omitempty",
+			qb:   New(),
+			rows: rows,
+			expStmts: []Statement{
+				{
+					Query: "INSERT INTO product (name, status, note) VALUES (?, ?, ?), (?, DEFAULT, ?), (?, ?, ?)",
+					Args:  []interface{}{"foo", "ACTIVE", sql.NullString{Valid: true, String: "hoho"}, "bar", sql.NullString{}, "baz", "INACTIVE", sql.NullString{}},
+				},
+			},
+		},
+		{
+			desc: "batch",
+			qb:   New(WithBatchSize(2)),
+			rows: rows,
+			expStmts: []Statement{
+				{
+					Query: "INSERT INTO product (name, status, note) VALUES (?, ?, ?), (?, DEFAULT, ?)",
+					Args:  []interface{}{"foo", "ACTIVE", sql.NullString{Valid: true, String: "hoho"}, "bar", sql.NullString{}},
+				},
+				{
+					Query: "INSERT INTO product (name, status, note) VALUES (?, ?, ?)",
+					Args:  []interface{}{"baz", "INACTIVE", sql.NullString{}},
+				},
+			},
+		},
+		{
+			desc: "mysql upsert",
+			qb:   New().Upsert([]string{"name"}),
+			rows: rows[2:],
+			expStmts: []Statement{
+				{
+					Query: "INSERT INTO product (name, status, note) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE status = VALUES(status), note = VALUES(note)",
+					Args:  []interface{}{"baz", "INACTIVE", sql.NullString{}},
+				},
+			},
+		},
+		{
+			desc: "postgres upsert",
+			qb:   New(WithDialect(Postgres)).Upsert([]string{"name"}, "status"),
+			rows: rows[2:],
+			expStmts: []Statement{
+				{
+					Query: "INSERT INTO product (name, status, note) VALUES (?, ?, ?) ON CONFLICT (name) DO UPDATE SET status = EXCLUDED.status",
+					Args:  []interface{}{"baz", "INACTIVE", sql.NullString{}},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			stmts, err := tc.qb.BuildInsert("product", tc.rows)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expStmts, stmts)
+		})
+	}
+
+	t.Run("batch never exceed placeholder limit", func(t *testing.T) {
+		rows := make([]InsertProduct, 30000)
+		stmts, err := New().BuildInsert("product", rows)
+		assert.Nil(t, err)
+		assert.Len(t, stmts, 2)
+		for _, stmt := range stmts {
+			assert.LessOrEqual(t, len(stmt.Args), maxPlaceholders)
+		}
+	})
+
+	t.Run("invalid rows", func(t *testing.T) {
+		_, err := New().BuildInsert("product", []InsertProduct{})
+		assert.NotNil(t, err)
+
+		_, err = New().BuildInsert("product", []int{1})
+		assert.NotNil(t, err)
+	})
+	t.Run("postgres upsert without conflict columns", func(t *testing.T) {
+		_, err := New(WithDialect(Postgres)).Upsert(nil).BuildInsert("product", []InsertProduct{{}})
+		assert.ErrorIs(t, err, ErrInvalidParam)
+
+		_, err = New().Upsert(nil).BuildInsert("product", []InsertProduct{{}})
+		assert.Nil(t, err)
+	})
+}
+
+func Test_Dialect_Rebind(t *testing.T) {
+	assert.Equal(t, "a = ? AND b = ?", MySQL.Rebind("a = ? AND b = ?"))
+	assert.Equal(t, "a = $1 AND b = $2", Postgres.Rebind("a = ? AND b = ?"))
+}
@@ -0,0 +1,114 @@
+package qbuilder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// WithDefaultLimit set the limit used when the param has no limit, default is 10.
+//
+// It can also be set per param struct with tag:"default", e.g: `param:"limit" default:"20"`
+func WithDefaultLimit(limit int64) Option {
+	return func(qb *queryBuilder) {
+		if limit > 0 {
+			qb.limitDefault = limit
+			qb.limit = limit
+		}
+	}
+}
+
+// WithMaxLimit set the maximum limit, a bigger limit is clamped to max.
+//
+// It can also be set per param struct with tag:"max", e.g: `param:"limit" max:"100"`
+func WithMaxLimit(max int64) Option {
+	return func(qb *queryBuilder) {
+		qb.limitMax = max
+	}
+}
+
+// WithMaxPage set the maximum page, a bigger page is clamped to max.
+//
+// It can also be set per param struct with tag:"max", e.g: `param:"page" max:"50"`
+func WithMaxPage(max int64) Option {
+	return func(qb *queryBuilder) {
+		qb.pageMax = max
+	}
+}
+
+// WithMaxOffset set the maximum offset (page-1)*limit, a deeper page is clamped to the last page within max.
+func WithMaxOffset(max int64) Option {
+	return func(qb *queryBuilder) {
+		qb.offsetMax = max
+	}
+}
+
+// WithStrictLimit return ErrLimitExceeded instead of clamping when the limit, page or offset exceed the maximum.
+func WithStrictLimit() Option {
+	return func(qb *queryBuilder) {
+		qb.strictLimit = true
+	}
+}
+
+// ValidatePageAndLimit apply the default and maximum page and limit of the query builder.
+func (q *queryBuilder) ValidatePageAndLimit(p, l int64) (page int64, limit int64, err error) {
+	page, limit, exceeded := q.validatePageAndLimit(p, l)
+	if exceeded != "" {
+		return 0, 0, fmt.Errorf("%w: %s", ErrLimitExceeded, exceeded)
+	}
+	return page, limit, nil
+}
+
+// validatePageAndLimit return the reason if the page or limit exceed the maximum with WithStrictLimit,
+// or if the offset overflows.
+func (q *queryBuilder) validatePageAndLimit(p, l int64) (page int64, limit int64, exceeded string) {
+	page, limit = p, l
+	if page <= 0 {
+		page = defaultPage
+	}
+	if limit <= 0 {
+		limit = q.limitDefault
+	}
+
+	if q.limitMax > 0 && limit > q.limitMax {
+		if q.strictLimit {
+			return 0, 0, fmt.Sprintf("limit %d exceeds maximum %d", limit, q.limitMax)
+		}
+		limit = q.limitMax
+	}
+
+	if q.pageMax > 0 && page > q.pageMax {
+		if q.strictLimit {
+			return 0, 0, fmt.Sprintf("page %d exceeds maximum %d", page, q.pageMax)
+		}
+		page = q.pageMax
+	}
+
+	// compare with division, (page-1)*limit may overflow int64
+	if q.offsetMax > 0 && page-1 > q.offsetMax/limit {
+		if q.strictLimit {
+			return 0, 0, fmt.Sprintf("offset of page %d with limit %d exceeds maximum %d", page, limit, q.offsetMax)
+		}
+		page = q.offsetMax/limit + 1
+	}
+
+	if page-1 > math.MaxInt64/limit {
+		return 0, 0, fmt.Sprintf("offset of page %d with limit %d overflows", page, limit)
+	}
+
+	return page, limit, ""
+}
+
+// parseLimitTag parse the int64 value of tag:"default" or tag:"max", return def if the tag is empty.
+func parseLimitTag(tag string, def int64) (int64, error) {
+	if tag == "" {
+		return def, nil
+	}
+
+	v, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("should be a positive integer, got %q", tag)
+	}
+
+	return v, nil
+}
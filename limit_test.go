@@ -0,0 +1,144 @@
+package qbuilder
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamLimitTag struct {
+	Page  int64 `param:"page" max:"5"`
+	Limit int64 `param:"limit" default:"20" max:"50"`
+}
+
+type ParamInvalidLimitTag struct {
+	Limit int64 `param:"limit" max:"ten"`
+}
+
+func Test_QBuilder_Limit(t *testing.T) {
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		param     interface{}
+		expClause string
+		expErr    error
+	}{
+		{
+			desc:      "default limit option",
+			opt:       []Option{WithDefaultLimit(25)},
+			param:     &ParamPaginationInt64{},
+			expClause: " WHERE 1=1 LIMIT 0, 25",
+		},
+		{
+			desc:      "max limit option is clamped",
+			opt:       []Option{WithMaxLimit(100)},
+			param:     &ParamPaginationInt64{Limit: 10000000},
+			expClause: " WHERE 1=1 LIMIT 0, 100",
+		},
+		{
+			desc:   "max limit option with strict limit",
+			opt:    []Option{WithMaxLimit(100), WithStrictLimit()},
+			param:  &ParamPaginationInt64{Limit: 10000000},
+			expErr: ErrLimitExceeded,
+		},
+		{
+			desc:      "max page option is clamped",
+			opt:       []Option{WithMaxPage(3)},
+			param:     &ParamPaginationInt64{Page: 4},
+			expClause: " WHERE 1=1 LIMIT 20, 10",
+		},
+		{
+			desc:      "max offset option is clamped",
+			opt:       []Option{WithMaxOffset(1000)},
+			param:     &ParamPaginationInt64{Page: 1000, Limit: 100},
+			expClause: " WHERE 1=1 LIMIT 1000, 100",
+		},
+		{
+			desc:   "max offset option with strict limit",
+			opt:    []Option{WithMaxOffset(1000), WithStrictLimit()},
+			param:  &ParamPaginationInt64{Page: 12, Limit: 100},
+			expErr: ErrLimitExceeded,
+		},
+		{
+			desc:      "max offset option with overflowing page is clamped",
+			opt:       []Option{WithMaxOffset(1000)},
+			param:     &ParamPaginationInt64{Page: math.MaxInt64, Limit: 100},
+			expClause: " WHERE 1=1 LIMIT 1000, 100",
+		},
+		{
+			desc:   "max offset option with overflowing page and strict limit",
+			opt:    []Option{WithMaxOffset(1000), WithStrictLimit()},
+			param:  &ParamPaginationInt64{Page: math.MaxInt64, Limit: 100},
+			expErr: ErrLimitExceeded,
+		},
+		{
+			desc:   "overflowing offset",
+			param:  &ParamPaginationInt64{Page: math.MaxInt64, Limit: 100},
+			expErr: ErrLimitExceeded,
+		},
+		{
+			desc:      "default and max tag",
+			param:     &ParamLimitTag{Page: 10},
+			expClause: " WHERE 1=1 LIMIT 80, 20",
+		},
+		{
+			desc:      "tag override option",
+			opt:       []Option{WithMaxLimit(10)},
+			param:     &ParamLimitTag{Limit: 100},
+			expClause: " WHERE 1=1 LIMIT 0, 50",
+		},
+		{
+			desc:   "invalid tag",
+			param:  &ParamInvalidLimitTag{},
+			expErr: ErrInvalidParam,
+		},
+		{
+			desc:      "postgres",
+			opt:       []Option{WithDialect(Postgres)},
+			param:     &ParamPaginationInt64{Page: 3, Limit: 5},
+			expClause: " WHERE 1=1 LIMIT 5 OFFSET 10",
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, _, err := New(tc.opt...).Build(tc.param)
+			if tc.expErr != nil {
+				assert.True(t, errors.Is(err, tc.expErr), err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+		})
+	}
+
+	t.Run("field error", func(t *testing.T) {
+		param := ParamLimitTag{Page: 6}
+		_, _, err := New(WithStrictLimit()).Build(&param)
+
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, "Page", fe.Field)
+		assert.Equal(t, "page", fe.Param)
+	})
+}
+
+func Test_QBuilder_ValidatePageAndLimit(t *testing.T) {
+	qb := New(WithDefaultLimit(20), WithMaxLimit(100))
+
+	page, limit, err := qb.ValidatePageAndLimit(0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), page)
+	assert.Equal(t, int64(20), limit)
+
+	page, limit, err = qb.ValidatePageAndLimit(2, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), page)
+	assert.Equal(t, int64(100), limit)
+
+	_, _, err = New(WithMaxLimit(100), WithStrictLimit()).ValidatePageAndLimit(1, 1000)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+}
@@ -0,0 +1,311 @@
+package qbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// VariadicArity is the arity of an operator accepting a slice of any length, bound as an expanded list.
+const VariadicArity = -1
+
+// Operator is a param operator, the suffix of tag:"param", e.g: param:"flags__bitand" => bitand
+type Operator struct {
+	// Name is the suffix without the double underscore, e.g: bitand
+	Name string
+
+	// Arity is the number of values of the operator:
+	//
+	//	0 or 1:        a scalar, bound to every ? of the template, e.g: (%s & ?) = ?
+	//	n > 1:         a slice of exactly n values, bound in order, e.g: ST_Distance_Sphere(%s, POINT(?, ?)) <= ?
+	//	VariadicArity: a slice of any length, expanded into the single ?, e.g: %s IN (SELECT id FROM t WHERE parent_id IN (?))
+	Arity int
+
+	// Kinds is the accepted kinds of the value, or the slice element, empty accept any supported type.
+	Kinds []reflect.Kind
+
+	// Template is the SQL predicate, %s is the column.
+	Template string
+
+	// Templates override Template per dialect, an operator without template for the dialect return an error.
+	Templates map[Dialect]string
+
+	operand string // comparison operand of the builtin operator, e.g: >=
+	builtin bool   // rendered by qbuilder, the template is informative, e.g: __like is OR'ed for each pattern
+}
+
+// IsSlice report whether the operator only accept a slice value.
+func (o Operator) IsSlice() bool {
+	return o.Arity > 1 || o.Arity == VariadicArity
+}
+
+// TemplateOf return the template of the dialect.
+func (o Operator) TemplateOf(d Dialect) string {
+	if tpl, ok := o.Templates[d]; ok {
+		return tpl
+	}
+	return o.Template
+}
+
+func (o Operator) accept(k reflect.Kind) bool {
+	if len(o.Kinds) == 0 {
+		return true
+	}
+	for _, v := range o.Kinds {
+		if v == k {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	operatorsMu sync.RWMutex
+	operators   = map[string]Operator{
+		"":             {Template: "%s = ?", operand: "="},
+		"gt":           {Template: "%s > ?", operand: ">"},
+		"gte":          {Template: "%s >= ?", operand: ">="},
+		"lt":           {Template: "%s < ?", operand: "<"},
+		"lte":          {Template: "%s <= ?", operand: "<="},
+		"neq":          {Template: "%s != ?", operand: "!="},
+		"search":       {Kinds: stringKinds, Template: searchClauseMatchFmt, Templates: map[Dialect]string{Postgres: "to_tsvector(%s) @@ plainto_tsquery(?)"}},
+		"regex":        {Kinds: stringKinds, Template: "%s REGEXP ?", Templates: map[Dialect]string{Postgres: "%s ~ ?"}},
+		"iregex":       {Kinds: stringKinds, Template: "REGEXP_LIKE(%s, ?, 'i')", Templates: map[Dialect]string{Postgres: "%s ~* ?"}},
+		"iexact":       {Kinds: stringKinds, Template: "LOWER(%s) = LOWER(?)", Templates: map[Dialect]string{Postgres: "%s ILIKE ?"}},
+		"nin":          {Arity: VariadicArity, Template: "%s NOT IN (?)", operand: "NOT IN"},
+		"like":         {Arity: VariadicArity, Kinds: stringKinds, Template: "%s LIKE ?"},
+		"overlap":      {Arity: VariadicArity, Template: "JSON_OVERLAPS(%s, ?)", Templates: map[Dialect]string{Postgres: "EXISTS (SELECT 1 FROM jsonb_array_elements(%s) AS e WHERE ?::jsonb @> e)"}},
+		"contains_all": {Arity: VariadicArity, Template: "JSON_CONTAINS(%s, ?)", Templates: map[Dialect]string{Postgres: "%s @> ?::jsonb"}},
+		"any":          {Arity: VariadicArity, Template: "%s IN (?)", Templates: map[Dialect]string{Postgres: "%s = ANY(?)"}},
+	}
+
+	stringKinds = []reflect.Kind{reflect.String}
+)
+
+func init() {
+	for name, op := range operators {
+		op.Name = name
+		op.builtin = true
+		operators[name] = op
+	}
+}
+
+// Register register a custom operator, usable as param suffix, e.g:
+//
+//	qbuilder.Register(qbuilder.Operator{
+//		Name:     "near",
+//		Arity:    3,
+//		Kinds:    []reflect.Kind{reflect.Float64},
+//		Template: "ST_Distance_Sphere(%s, POINT(?, ?)) <= ?",
+//		Templates: map[qbuilder.Dialect]string{
+//			qbuilder.Postgres: "ST_DWithin(%s::geography, ST_MakePoint(?, ?)::geography, ?)",
+//		},
+//	})
+//
+// It should be called on init, before building any query.
+func Register(op Operator) error {
+	if op.Name == "" || strings.Contains(op.Name, "__") {
+		return fmt.Errorf("%w: invalid operator name %q", ErrInvalidOperator, op.Name)
+	}
+	if op.Arity < VariadicArity {
+		return fmt.Errorf("%w: invalid arity %d of operator %q", ErrInvalidOperator, op.Arity, op.Name)
+	}
+	if op.Template == "" && len(op.Templates) == 0 {
+		return fmt.Errorf("%w: operator %q has no template", ErrInvalidOperator, op.Name)
+	}
+
+	if err := checkTemplate(op, op.Template); err != nil {
+		return err
+	}
+
+	templates := make(map[Dialect]string, len(op.Templates))
+	for d, tpl := range op.Templates {
+		if err := checkTemplate(op, tpl); err != nil {
+			return err
+		}
+		templates[d] = tpl
+	}
+
+	op.Templates = templates
+	op.Kinds = append([]reflect.Kind(nil), op.Kinds...)
+	op.operand, op.builtin = "", false
+
+	operatorsMu.Lock()
+	defer operatorsMu.Unlock()
+
+	if _, ok := operators[op.Name]; ok {
+		return fmt.Errorf("%w: operator %q is already registered", ErrInvalidOperator, op.Name)
+	}
+	operators[op.Name] = op
+
+	// the cached plans may have rejected the operator
+	resetPlans()
+
+	return nil
+}
+
+// RegisterOperator register a scalar custom operator, a shorthand of Register, e.g:
+//
+//	RegisterOperator("bitand", "(%s & ?) = ?") => param:"flags__bitand" db:"flags"
+//
+// %s is the column, the value is bound to every ? of the template.
+func RegisterOperator(name, template string) error {
+	return Register(Operator{Name: name, Arity: 1, Template: template})
+}
+
+// LookupOperator return the registered operator, including the builtin operators.
+func LookupOperator(name string) (Operator, bool) {
+	return lookupOperator(name)
+}
+
+// checkTemplate validate the template of the operator, an empty template is the unsupported dialect.
+func checkTemplate(op Operator, tpl string) error {
+	if tpl == "" {
+		return nil
+	}
+	if strings.Count(tpl, "%s") != 1 {
+		return fmt.Errorf("%w: template of operator %q should have exactly one %%s", ErrInvalidOperator, op.Name)
+	}
+
+	n := strings.Count(tpl, "?")
+	switch {
+	case op.Arity == VariadicArity && n != 1:
+		return fmt.Errorf("%w: template of variadic operator %q should have exactly one ?", ErrInvalidOperator, op.Name)
+	case op.Arity > 1 && n != op.Arity:
+		return fmt.Errorf("%w: template of operator %q should have %d ?, got %d", ErrInvalidOperator, op.Name, op.Arity, n)
+	case n == 0:
+		return fmt.Errorf("%w: template of operator %q has no ?", ErrInvalidOperator, op.Name)
+	}
+
+	return nil
+}
+
+func lookupOperator(name string) (Operator, bool) {
+	operatorsMu.RLock()
+	defer operatorsMu.RUnlock()
+
+	op, ok := operators[name]
+	return op, ok
+}
+
+// parseParam split the param into name and operator, e.g: created_at__gte => created_at, gte
+func parseParam(param string) (name, op string) {
+	i := strings.LastIndex(param, "__")
+	if i < 0 {
+		return param, ""
+	}
+	return param[:i], param[i+2:]
+}
+
+// plans cache the result of compileParamType by the param struct type.
+var plans sync.Map
+
+type plan struct {
+	err error
+}
+
+func resetPlans() {
+	plans.Range(func(key, _ interface{}) bool {
+		plans.Delete(key)
+		return true
+	})
+}
+
+// compileParamType validate the param struct type once, e.g: unknown operator or unsupported type.
+func compileParamType(t reflect.Type) error {
+	if p, ok := plans.Load(t); ok {
+		return p.(plan).err
+	}
+
+	err := validateParamType(t)
+	plans.Store(t, plan{err: err})
+
+	return err
+}
+
+// makeClauseOperator render the registered operator with the template of the dialect.
+// The empty string and false are skipped, as the builtin operators.
+func (c *cursor) makeClauseOperator(op Operator) (clause string, args []interface{}, skip bool) {
+	var (
+		val  interface{}
+		vals []interface{}
+	)
+
+	if op.IsSlice() {
+		skip = c.field.Len() < 1
+	} else {
+		var ok bool
+		val, ok = setValue(c.field)
+		skip = !ok || c.field.IsZero() && !c.ptr && (c.field.Kind() == reflect.String || c.field.Kind() == reflect.Bool)
+	}
+	if skip {
+		return
+	}
+
+	if op.IsSlice() {
+		vals = make([]interface{}, c.field.Len())
+		for i := range vals {
+			if vals[i], skip, c.err = c.resolveOperatorValue(c.field.Index(i).Interface()); skip || c.err != nil {
+				skip = true
+				return
+			}
+		}
+	} else if val, skip, c.err = c.resolveOperatorValue(val); skip || c.err != nil {
+		skip = true
+		return
+	}
+
+	tpl := op.TemplateOf(c.qb.dialect)
+	if tpl == "" {
+		c.err = fmt.Errorf("operator %q is not supported by %s", op.Name, c.qb.dialect)
+		skip = true
+		return
+	}
+	clause = " AND " + fmt.Sprintf(tpl, c.Column())
+
+	switch {
+	case op.Arity == VariadicArity:
+		if clause, args, c.err = sqlx.In(clause, vals); c.err != nil {
+			skip = true
+		}
+	case op.Arity > 1:
+		if len(vals) != op.Arity {
+			c.err = fmt.Errorf("operator %q expect %d values, got %d", op.Name, op.Arity, len(vals))
+			skip = true
+			return
+		}
+		args = append(args, vals...)
+	default:
+		for i := strings.Count(tpl, "?"); i > 0; i-- {
+			args = append(args, val)
+		}
+	}
+
+	return
+}
+
+// resolveOperatorValue resolve the value, or the slice element, as the builtin operators do:
+// RelativeTime against the clock, time in the builder location and time.Duration in tag:"unit".
+func (c *cursor) resolveOperatorValue(val interface{}) (resolved interface{}, skip bool, err error) {
+	switch v := val.(type) {
+	case RelativeTime:
+		if v.IsZero() {
+			return nil, true, nil
+		}
+		return c.qb.normalizeTime(v.Resolve(c.qb.now)), false, nil
+	case time.Time:
+		return c.qb.normalizeTime(v), false, nil
+	case time.Duration:
+		unit, err := c.DurationUnit()
+		if err != nil {
+			return nil, true, err
+		}
+		return int64(v / unit), false, nil
+	default:
+		return val, false, nil
+	}
+}
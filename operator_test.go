@@ -0,0 +1,355 @@
+package qbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamUnknownOperator struct {
+	CreatedAt string `param:"created_at__gtee" db:"created_at"`
+}
+
+type ParamBitAnd struct {
+	Flags  int64  `param:"flags__bitand" db:"flags"`
+	Status string `param:"status__bitand" db:"status"`
+}
+
+type ParamNumericRegex struct {
+	Age int `param:"age__regex" db:"age"`
+}
+
+type ParamNumericSearch struct {
+	N sql.NullInt64 `param:"n__search" db:"n"`
+}
+
+type ParamNumericIExact struct {
+	F *float64 `param:"f__iexact" db:"f"`
+}
+
+type ParamLateOperator struct {
+	Parent int64 `param:"category__late_subtree" db:"category_id"`
+}
+
+type ParamRegisteredOperator struct {
+	Near      []float64 `param:"location__near" db:"location"`
+	Subtree   []int64   `param:"category__in_subtree" db:"category_id"`
+	Published bool      `param:"published__pg_only" db:"published"`
+}
+
+type ParamOperatorTime struct {
+	Since   RelativeTime  `param:"created_at__not_before" db:"created_at"`
+	Expired time.Time     `param:"expired_at__not_before" db:"expired_at"`
+	Timeout time.Duration `param:"timeout__not_before" db:"timeout_ms" unit:"ms"`
+}
+
+type ParamOperatorDurations struct {
+	Between []time.Duration `param:"timeout__between" db:"timeout_ms" unit:"ms"`
+	AnyOf   []time.Duration `param:"retry__any_of" db:"retry_s" unit:"s"`
+}
+
+type ParamOperatorTrunc struct {
+	Day time.Time `param:"created_at__not_before" db:"created_at" trunc:"day"`
+}
+
+type ParamInvalidOperatorKind struct {
+	Near []string `param:"location__near" db:"location"`
+}
+
+func Test_parseParam(t *testing.T) {
+	testCase := []struct {
+		param   string
+		expName string
+		expOp   string
+	}{
+		{param: "name", expName: "name", expOp: ""},
+		{param: "created_at__gte", expName: "created_at", expOp: "gte"},
+		{param: "tags__contains_all", expName: "tags", expOp: "contains_all"},
+		{param: "meta__key__neq", expName: "meta__key", expOp: "neq"},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.param), func(t *testing.T) {
+			name, op := parseParam(tc.param)
+			assert.Equal(t, tc.expName, name)
+			assert.Equal(t, tc.expOp, op)
+		})
+	}
+}
+
+func Test_QBuilder_UnknownOperator(t *testing.T) {
+	_, _, err := New().Build(&ParamUnknownOperator{CreatedAt: "2024-01-31"})
+	assert.ErrorIs(t, err, ErrInvalidOperator)
+
+	_, err = For[ParamUnknownOperator]()
+	assert.ErrorIs(t, err, ErrInvalidOperator)
+}
+
+// unregisterOperator remove the custom operator registered by the test, so the test can run again.
+func unregisterOperator(t *testing.T, name string) {
+	t.Cleanup(func() {
+		operatorsMu.Lock()
+		defer operatorsMu.Unlock()
+
+		delete(operators, name)
+		resetPlans()
+	})
+}
+
+func Test_QBuilder_OperatorKind(t *testing.T) {
+	for _, param := range []interface{}{&ParamNumericRegex{}, &ParamNumericSearch{}, &ParamNumericIExact{}} {
+		t.Run(fmt.Sprintf("%T", param), func(t *testing.T) {
+			_, _, err := New().Build(param)
+			assert.ErrorIs(t, err, ErrInvalidOperator)
+		})
+	}
+}
+
+func Test_RegisterOperator(t *testing.T) {
+	assert.Nil(t, RegisterOperator("bitand", "(%s & ?) = ?"))
+	unregisterOperator(t, "bitand")
+
+	t.Run("build", func(t *testing.T) {
+		clause, args, err := New().Build(&ParamBitAnd{Flags: 4})
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND (flags & ?) = ? LIMIT 0, 10", clause)
+		assert.Equal(t, []interface{}{int64(4), int64(4)}, args)
+	})
+
+	t.Run("invalidate the cached plan", func(t *testing.T) {
+		_, _, err := New().Build(&ParamLateOperator{Parent: 1})
+		assert.ErrorIs(t, err, ErrInvalidOperator)
+
+		assert.Nil(t, RegisterOperator("late_subtree", "%s IN (SELECT descendant FROM category_tree WHERE ancestor = ?)"))
+		unregisterOperator(t, "late_subtree")
+
+		clause, args, err := New().Build(&ParamLateOperator{Parent: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND category_id IN (SELECT descendant FROM category_tree WHERE ancestor = ?) LIMIT 0, 10", clause)
+		assert.Equal(t, []interface{}{int64(1)}, args)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.ErrorIs(t, RegisterOperator("gt", "%s > ?"), ErrInvalidOperator)
+		assert.ErrorIs(t, RegisterOperator("bitand", "(%s & ?) = ?"), ErrInvalidOperator)
+		assert.ErrorIs(t, RegisterOperator("", "%s = ?"), ErrInvalidOperator)
+		assert.ErrorIs(t, RegisterOperator("a__b", "%s = ?"), ErrInvalidOperator)
+		assert.ErrorIs(t, RegisterOperator("nocolumn", "1 = ?"), ErrInvalidOperator)
+	})
+}
+
+func Test_Register(t *testing.T) {
+	assert.Nil(t, Register(Operator{
+		Name:     "near",
+		Arity:    3,
+		Kinds:    []reflect.Kind{reflect.Float64},
+		Template: "ST_Distance_Sphere(%s, POINT(?, ?)) <= ?",
+		Templates: map[Dialect]string{
+			Postgres: "ST_DWithin(%s::geography, ST_MakePoint(?, ?)::geography, ?)",
+		},
+	}))
+	assert.Nil(t, Register(Operator{
+		Name:     "in_subtree",
+		Arity:    VariadicArity,
+		Kinds:    []reflect.Kind{reflect.Int64},
+		Template: "%s IN (SELECT descendant FROM category_tree WHERE ancestor IN (?))",
+	}))
+	assert.Nil(t, Register(Operator{
+		Name:      "pg_only",
+		Templates: map[Dialect]string{Postgres: "%s IS NOT DISTINCT FROM ?"},
+	}))
+	assert.Nil(t, RegisterOperator("not_before", "%s >= ?"))
+	assert.Nil(t, Register(Operator{Name: "between", Arity: 2, Template: "%s BETWEEN ? AND ?"}))
+	assert.Nil(t, Register(Operator{Name: "any_of", Arity: VariadicArity, Template: "%s IN (?)"}))
+	for _, name := range []string{"near", "in_subtree", "pg_only", "not_before", "between", "any_of"} {
+		unregisterOperator(t, name)
+	}
+
+	utc7 := time.FixedZone("UTC+7", 7*60*60)
+	now := time.Date(2024, 1, 31, 20, 0, 0, 0, time.UTC)
+
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		param     interface{}
+		expClause string
+		expArgs   []interface{}
+		expErr    error
+	}{
+		{
+			desc:      "mysql",
+			param:     &ParamRegisteredOperator{Near: []float64{106.8, -6.2, 500}, Subtree: []int64{1, 2}},
+			expClause: " WHERE 1=1 AND ST_Distance_Sphere(location, POINT(?, ?)) <= ? AND category_id IN (SELECT descendant FROM category_tree WHERE ancestor IN (?, ?)) LIMIT 0, 10",
+			expArgs:   []interface{}{106.8, -6.2, float64(500), int64(1), int64(2)},
+		},
+		{
+			desc:      "postgres",
+			opt:       []Option{WithDialect(Postgres)},
+			param:     &ParamRegisteredOperator{Near: []float64{106.8, -6.2, 500}, Published: true},
+			expClause: " WHERE 1=1 AND ST_DWithin(location::geography, ST_MakePoint(?, ?)::geography, ?) AND published IS NOT DISTINCT FROM ? LIMIT 10 OFFSET 0",
+			expArgs:   []interface{}{106.8, -6.2, float64(500), true},
+		},
+		{
+			desc:      "empty values",
+			param:     &ParamRegisteredOperator{Subtree: []int64{}},
+			expClause: " WHERE 1=1 LIMIT 0, 10",
+		},
+		{
+			desc:   "wrong number of values",
+			param:  &ParamRegisteredOperator{Near: []float64{106.8, -6.2}},
+			expErr: ErrInvalidParam,
+		},
+		{
+			desc:   "unsupported dialect",
+			param:  &ParamRegisteredOperator{Published: true},
+			expErr: ErrInvalidParam,
+		},
+		{
+			desc:   "unsupported kind",
+			param:  &ParamInvalidOperatorKind{Near: []string{"a"}},
+			expErr: ErrInvalidOperator,
+		},
+		{
+			desc: "time values",
+			opt:  []Option{WithLocation(utc7), WithClock(func() time.Time { return now })},
+			param: &ParamOperatorTime{
+				Since:   MustParseRelativeTime("today"),
+				Expired: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+				Timeout: 2 * time.Second,
+			},
+			expClause: " WHERE 1=1 AND created_at >= ? AND expired_at >= ? AND timeout_ms >= ? LIMIT 0, 10",
+			expArgs: []interface{}{time.Date(2024, 2, 1, 0, 0, 0, 0, utc7), time.Date(2024, 1, 31, 17, 0, 0, 0, utc7),
+				int64(2000)},
+		},
+		{
+			desc:      "empty time values",
+			param:     &ParamOperatorTime{},
+			expClause: " WHERE 1=1 AND timeout_ms >= ? LIMIT 0, 10",
+			expArgs:   []interface{}{int64(0)},
+		},
+		{
+			desc:      "duration slices",
+			param:     &ParamOperatorDurations{Between: []time.Duration{time.Second, 2 * time.Second}, AnyOf: []time.Duration{time.Minute, time.Hour}},
+			expClause: " WHERE 1=1 AND timeout_ms BETWEEN ? AND ? AND retry_s IN (?, ?) LIMIT 0, 10",
+			expArgs:   []interface{}{int64(1000), int64(2000), int64(60), int64(3600)},
+		},
+		{
+			desc:   "trunc",
+			param:  &ParamOperatorTrunc{Day: now},
+			expErr: ErrInvalidOperator,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(tc.opt...).Build(tc.param)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+
+	t.Run("not an in list", func(t *testing.T) {
+		param := ParamRegisteredOperator{Near: []float64{106.8, -6.2, 500}, Subtree: []int64{1, 2, 3}}
+		clause, _, err := New(WithDialect(Postgres), WithInListThreshold(2, InListJSONTable)).Build(&param)
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND ST_DWithin(location::geography, ST_MakePoint(?, ?)::geography, ?) AND category_id IN (SELECT descendant FROM category_tree WHERE ancestor IN (?, ?, ?)) LIMIT 10 OFFSET 0", clause)
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		op, ok := LookupOperator("gte")
+		assert.True(t, ok)
+		assert.Equal(t, "%s >= ?", op.TemplateOf(Postgres))
+
+		op, ok = LookupOperator("near")
+		assert.True(t, ok)
+		assert.True(t, op.IsSlice())
+
+		_, ok = LookupOperator("gtee")
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.ErrorIs(t, Register(Operator{Name: "no_template"}), ErrInvalidOperator)
+		assert.ErrorIs(t, Register(Operator{Name: "bad_arity", Arity: -2, Template: "%s = ?"}), ErrInvalidOperator)
+		assert.ErrorIs(t, Register(Operator{Name: "between2", Arity: 2, Template: "%s > ?"}), ErrInvalidOperator)
+		assert.ErrorIs(t, Register(Operator{Name: "variadic2", Arity: VariadicArity, Template: "%s IN (?) OR %s IN (?)"}), ErrInvalidOperator)
+		assert.ErrorIs(t, Register(Operator{Name: "no_value", Template: "%s IS NULL"}), ErrInvalidOperator)
+		assert.ErrorIs(t, Register(Operator{Name: "bad_dialect", Template: "%s = ?", Templates: map[Dialect]string{Postgres: "= ?"}}), ErrInvalidOperator)
+	})
+}
+
+// the vet analyzer is a separate module, it keep its own copy of the builtin operators and tag values
+func Test_AnalyzerOperators(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "cmd/qbuildervet/analyzer/analyzer.go", nil, 0)
+	assert.Nil(t, err)
+
+	lists := make(map[string][]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Values) != len(spec.Names) {
+			return true
+		}
+		for i, name := range spec.Names {
+			lit, ok := spec.Values[i].(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			for _, elt := range lit.Elts {
+				if v, ok := elt.(*ast.BasicLit); ok && v.Kind == token.STRING {
+					s, _ := strconv.Unquote(v.Value)
+					lists[name.Name] = append(lists[name.Name], s)
+				}
+			}
+		}
+		return true
+	})
+
+	exp := make(map[string][]string)
+	operatorsMu.RLock()
+	for name, op := range operators {
+		switch {
+		case !op.builtin || name == "":
+			continue
+		case op.IsSlice():
+			exp["sliceOperators"] = append(exp["sliceOperators"], name)
+		default:
+			exp["scalarOperators"] = append(exp["scalarOperators"], name)
+		}
+		if reflect.DeepEqual(op.Kinds, stringKinds) {
+			exp["stringOperators"] = append(exp["stringOperators"], name)
+		}
+	}
+	operatorsMu.RUnlock()
+
+	for name, m := range map[string]reflect.Value{
+		"emptyPolicies": reflect.ValueOf(emptySlicePolicies),
+		"truncUnits":    reflect.ValueOf(truncFuncs),
+		"durationUnits": reflect.ValueOf(durationUnits),
+		"aggFuncs":      reflect.ValueOf(aggFuncs),
+	} {
+		for _, k := range m.MapKeys() {
+			exp[name] = append(exp[name], k.String())
+		}
+	}
+
+	for name, l := range exp {
+		sort.Strings(l)
+		sort.Strings(lists[name])
+		assert.Equal(t, l, lists[name], name)
+	}
+}
@@ -0,0 +1,60 @@
+package qbuilder
+
+// Page is the paginated result, ready to be encoded as JSON.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Page       int64  `json:"page"`
+	Limit      int64  `json:"limit"`
+	Total      *int64 `json:"total,omitempty"`
+	TotalPages *int64 `json:"total_pages,omitempty"`
+	HasNext    bool   `json:"has_next"`
+	HasPrev    bool   `json:"has_prev"`
+}
+
+// NewPage create the page from the scanned items.
+//
+// If items was fetched with WithExtraLimit, the extra row is trimmed and used to determine has_next.
+// total is optional, pass nil if the count query is not executed.
+func NewPage[T any](items []T, total *int64, page, limit int64) Page[T] {
+	page, limit = ValidatePageAndLimit(page, limit)
+
+	p := Page[T]{
+		Items:   items,
+		Page:    page,
+		Limit:   limit,
+		HasPrev: page > 1,
+	}
+
+	if int64(len(items)) > limit {
+		p.Items = items[:limit]
+		p.HasNext = true
+	}
+
+	if p.Items == nil {
+		p.Items = []T{}
+	}
+
+	if total != nil {
+		totalPages := (*total + limit - 1) / limit
+		p.Total = total
+		p.TotalPages = &totalPages
+		p.HasNext = page < totalPages
+	}
+
+	return p
+}
+
+// PageOf create the page using the page and limit of the query builder, should be called after Build.
+func PageOf[T any](q *queryBuilder, items []T, total *int64) Page[T] {
+	return NewPage(items, total, q.Page(), q.Limit())
+}
+
+// Page return the current page, should be called after Build.
+func (q *queryBuilder) Page() int64 {
+	return q.page
+}
+
+// Limit return the current limit (without the extra limit), should be called after Build.
+func (q *queryBuilder) Limit() int64 {
+	return q.limit
+}
@@ -0,0 +1,92 @@
+package qbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewPage(t *testing.T) {
+	total := func(v int64) *int64 { return &v }
+
+	testCase := []struct {
+		desc    string
+		items   []int
+		total   *int64
+		page    int64
+		limit   int64
+		expPage Page[int]
+	}{
+		{
+			desc:  "extra row is trimmed",
+			items: []int{1, 2, 3},
+			page:  1,
+			limit: 2,
+			expPage: Page[int]{
+				Items:   []int{1, 2},
+				Page:    1,
+				Limit:   2,
+				HasNext: true,
+			},
+		},
+		{
+			desc:  "last page without total",
+			items: []int{5},
+			page:  3,
+			limit: 2,
+			expPage: Page[int]{
+				Items:   []int{5},
+				Page:    3,
+				Limit:   2,
+				HasPrev: true,
+			},
+		},
+		{
+			desc:  "with total",
+			items: []int{3, 4},
+			total: total(5),
+			page:  2,
+			limit: 2,
+			expPage: Page[int]{
+				Items:      []int{3, 4},
+				Page:       2,
+				Limit:      2,
+				Total:      total(5),
+				TotalPages: total(3),
+				HasNext:    true,
+				HasPrev:    true,
+			},
+		},
+		{
+			desc:  "empty items",
+			total: total(0),
+			expPage: Page[int]{
+				Items:      []int{},
+				Page:       1,
+				Limit:      10,
+				Total:      total(0),
+				TotalPages: total(0),
+			},
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			assert.Equal(t, tc.expPage, NewPage(tc.items, tc.total, tc.page, tc.limit))
+		})
+	}
+}
+
+func Test_PageOf(t *testing.T) {
+	param := ParamPaginationInt64{Page: 2, Limit: 2}
+	qb := New(WithExtraLimit())
+	_, _, err := qb.Build(&param)
+	assert.Nil(t, err)
+
+	page := PageOf(qb, []string{"c", "d", "e"}, nil)
+	b, err := json.Marshal(page)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"items":["c","d"],"page":2,"limit":2,"has_next":true,"has_prev":true}`, string(b))
+}
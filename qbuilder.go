@@ -1,9 +1,11 @@
 package qbuilder
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
+	"time"
 )
 
 const (
@@ -14,8 +16,19 @@ const (
 	whereClauseMultiFmt      = " AND %s %s (?)"
 	whereClauseJsonFmt       = `JSON_CONTAINS(%s, '"%s"', '%s') = 1` // field, value, key
 	whereClauseJsonMemberFmt = "'%s' MEMBER OF (%s->'%s')"
+	searchClauseMatchFmt     = "MATCH(%s) AGAINST (? IN BOOLEAN MODE)"
+	countQueryFmt            = "SELECT COUNT(*) FROM (%s%s) AS qbuilder_count"
 )
 
+// supported aggregate functions for tag:"agg"
+var aggFuncs = map[string]string{
+	"count": "COUNT",
+	"sum":   "SUM",
+	"avg":   "AVG",
+	"min":   "MIN",
+	"max":   "MAX",
+}
+
 type queryBuilder struct {
 	page   int64
 	limit  int64
@@ -25,19 +38,63 @@ type queryBuilder struct {
 	customWhereClause     []string
 	customWhereClauseArgs []interface{}
 
+	// group by & custom having clause
+	groupBy                []string
+	customHavingClause     []string
+	customHavingClauseArgs []interface{}
+
+	// custom set clause
+	customSetClause     []string
+	customSetClauseArgs []interface{}
+
+	// insert
+	upsert       bool
+	conflictCols []string
+	updateCols   []string
+
 	// option
-	extraLimit int64
+	dialect          Dialect
+	extraLimit       int64
+	allowEmptyWhere  bool
+	batchSize        int
+	limitDefault     int64
+	limitMax         int64
+	pageMax          int64
+	offsetMax        int64
+	strictLimit      bool
+	concurrentCount  bool
+	sortableFields   []string
+	tieBreaker       string
+	defaultSort      []string
+	sortKeys         map[string]SortKey
+	regexValidation  bool
+	arrayBinder      func(interface{}) interface{}
+	inListThreshold  int
+	inListStrategy   InListStrategy
+	emptySlicePolicy EmptySlicePolicy
+	omitEmpty        bool
+	location         *time.Location
+	clock            func() time.Time
+	softDeleteCol    string
 
 	// result
-	args        []interface{}
-	whereClause string
+	now          time.Time // clock at build time
+	withDeleted  bool
+	onlyDeleted  bool
+	args         []interface{}
+	whereClause  string
+	havingArgs   []interface{}
+	havingClause string
+	filterCount  int
 }
 
 func New(opts ...Option) *queryBuilder {
 	qb := &queryBuilder{
-		whereClause: " WHERE 1=1",
-		page:        defaultPage,
-		limit:       defaultLimit,
+		whereClause:  " WHERE 1=1",
+		page:         defaultPage,
+		limit:        defaultLimit,
+		limitDefault: defaultLimit,
+		clock:        time.Now,
 	}
 
 	for _, opt := range opts {
@@ -59,6 +116,72 @@ func WithExtraLimit() Option {
 	}
 }
 
+// WithSortableFields restrict the fields allowed in sort_by,
+// other fields will return ErrSortFieldNotAllowed.
+//
+// It can also be set per param struct with tag:"sortable", e.g: `param:"sort_by" sortable:"name,created_at"`
+func WithSortableFields(fields ...string) Option {
+	return func(qb *queryBuilder) {
+		qb.sortableFields = append(qb.sortableFields, fields...)
+	}
+}
+
+// WithTieBreaker set the unique column (e.g: primary key) appended to ORDER BY when it isn't already present,
+// so the rows order is stable across pages. It follows the last sort direction.
+//
+// It can also be set per param struct with tag:"tiebreaker", e.g: `param:"sort_by" tiebreaker:"id"`
+func WithTieBreaker(col string) Option {
+	return func(qb *queryBuilder) {
+		qb.tieBreaker = col
+	}
+}
+
+// WithDefaultSort set the sort used when sort_by is empty, e.g: WithDefaultSort("-created_at", "name:asc").
+//
+// It can also be set per param struct with tag:"default", e.g: `param:"sort_by" default:"-created_at,name"`
+func WithDefaultSort(sortBy ...string) Option {
+	return func(qb *queryBuilder) {
+		qb.defaultSort = normalizeSort(sortBy)
+	}
+}
+
+// WithRegexValidation validate the regex of __regex and __iregex params before sending it to the database.
+//
+// It use the Go regexp syntax (RE2), which is close but not identical to MySQL ICU or Postgres regex.
+func WithRegexValidation() Option {
+	return func(qb *queryBuilder) {
+		qb.regexValidation = true
+	}
+}
+
+// WithArrayBinder wrap the slice bound as a single array parameter (Postgres `= ANY(?)`),
+// e.g: WithArrayBinder(func(v interface{}) interface{} { return pq.Array(v) }) for lib/pq.
+//
+// By default the slice is bound as is, which is supported by pgx.
+func WithArrayBinder(binder func(interface{}) interface{}) Option {
+	return func(qb *queryBuilder) {
+		qb.arrayBinder = binder
+	}
+}
+
+// WithOmitEmpty skip the zero value of every filter field, as if every param is tagged param:"name,omitempty".
+//
+// By default the zero value of numeric fields is rendered, e.g: int = 0,
+// while the empty string, zero time and false are skipped.
+// Use a pointer field to filter by the zero value, e.g: *int64.
+func WithOmitEmpty() Option {
+	return func(qb *queryBuilder) {
+		qb.omitEmpty = true
+	}
+}
+
+func (q *queryBuilder) bindArray(val interface{}) interface{} {
+	if q.arrayBinder != nil {
+		return q.arrayBinder(val)
+	}
+	return val
+}
+
 // Add custom where clause
 func (q *queryBuilder) AddWhereClause(wc string, args ...interface{}) *queryBuilder {
 	q.customWhereClause = append(q.customWhereClause, wc)
@@ -67,6 +190,22 @@ func (q *queryBuilder) AddWhereClause(wc string, args ...interface{}) *queryBuil
 	return q
 }
 
+// GroupBy add GROUP BY columns.
+// Fields tagged with agg:"sum" etc. will be rendered into the HAVING clause.
+func (q *queryBuilder) GroupBy(cols ...string) *queryBuilder {
+	q.groupBy = append(q.groupBy, cols...)
+
+	return q
+}
+
+// Add custom having clause
+func (q *queryBuilder) AddHavingClause(hc string, args ...interface{}) *queryBuilder {
+	q.customHavingClause = append(q.customHavingClause, hc)
+	q.customHavingClauseArgs = append(q.customHavingClauseArgs, args...)
+
+	return q
+}
+
 func (q *queryBuilder) handleParamPage(field reflect.Value) int64 {
 	page := defaultPage
 
@@ -86,7 +225,7 @@ func (q *queryBuilder) handleParamPage(field reflect.Value) int64 {
 }
 
 func (q *queryBuilder) handleParamLimit(field reflect.Value) int64 {
-	limit := defaultLimit
+	limit := q.limitDefault
 
 	switch val := field.Interface().(type) {
 	case int64:
@@ -103,42 +242,157 @@ func (q *queryBuilder) handleParamLimit(field reflect.Value) int64 {
 	return limit
 }
 
+// handleParamShortBy accept []string or comma separated string,
+// each item is `-field`, `field`, `field:asc` or `field:desc`.
+//
+// e.g: "-created_at,name:asc" => ["-created_at", "name"]
 func (q *queryBuilder) handleParamShortBy(field reflect.Value) []string {
-	var shortBy []string
-
-	if val, ok := field.Interface().([]string); ok {
-		shortBy = val
-	} else {
+	switch val := field.Interface().(type) {
+	case []string:
+		return normalizeSort(val)
+	case string:
+		return normalizeSort([]string{val})
+	default:
 		// default []string
 	}
 
-	return shortBy
+	return nil
+}
+
+// normalizeSort split the comma separated items and normalize each of them with normalizeSortItem.
+func normalizeSort(items []string) []string {
+	var sortBy []string
+	for _, item := range items {
+		for _, v := range strings.Split(item, ",") {
+			if v = normalizeSortItem(v); v != "" {
+				sortBy = append(sortBy, v)
+			}
+		}
+	}
+
+	return sortBy
 }
 
-func (q *queryBuilder) makeOrderByClause() string {
-	var orderByClause string
+// normalizeSortItem convert `field:asc|desc` into `field` or `-field`.
+func normalizeSortItem(v string) string {
+	v = strings.TrimSpace(v)
 
-	if len(q.sortBy) > 0 {
-		orderByClause += " ORDER BY "
-		for i, v := range q.sortBy {
-			if i > 0 {
-				orderByClause += ", "
-			}
+	key, direction, found := strings.Cut(v, ":")
+	if !found {
+		return v
+	}
 
-			if v[0] == '-' {
-				orderByClause += v[1:] + " DESC"
-			} else {
-				orderByClause += v + " ASC"
-			}
+	key = strings.TrimPrefix(strings.TrimSpace(key), "-")
+	if key == "" {
+		return ""
+	}
+
+	if strings.EqualFold(strings.TrimSpace(direction), "desc") {
+		return "-" + key
+	}
+	return key
+}
+
+// checkSortable check the sort fields against WithSortableFields, WithSortKey and tag:"sortable",
+// any field is allowed if all of them are empty. It return the first field that is not allowed.
+func (q *queryBuilder) checkSortable(sortBy []string, tagSortable string) (string, bool) {
+	sortable := append([]string{}, q.sortableFields...)
+	if tagSortable != "" {
+		sortable = append(sortable, strings.Split(tagSortable, ",")...)
+	}
+	restrict := len(sortable) > 0
+	for key, sk := range q.sortKeys {
+		sortable = append(sortable, key)
+		restrict = restrict || !sk.derived
+	}
+
+	if !restrict {
+		return "", true
+	}
+
+	for _, v := range sortBy {
+		if !contains(sortable, strings.TrimPrefix(v, "-")) {
+			return v, false
+		}
+	}
+
+	return "", true
+}
+
+// orderBy return the sort keys, fallback to the default sort and followed by the tie-breaker.
+func (q *queryBuilder) orderBy() []string {
+	sortBy := q.sortBy
+	if len(sortBy) == 0 {
+		sortBy = q.defaultSort
+	}
+
+	if q.tieBreaker == "" {
+		return sortBy
+	}
+
+	for _, v := range sortBy {
+		if strings.TrimPrefix(v, "-") == q.tieBreaker {
+			return sortBy
+		}
+	}
+
+	// follow the last sort direction
+	tieBreaker := q.tieBreaker
+	if len(sortBy) > 0 && strings.HasPrefix(sortBy[len(sortBy)-1], "-") {
+		tieBreaker = "-" + tieBreaker
+	}
+
+	return append(append([]string{}, sortBy...), tieBreaker)
+}
+
+func (q *queryBuilder) makeOrderByClause() (string, []interface{}) {
+	var (
+		items []string
+		args  []interface{}
+	)
+
+	for _, v := range q.orderBy() {
+		item, itemArgs, ok := q.makeOrderByItem(v)
+		if !ok {
+			continue
 		}
+		items = append(items, item)
+		args = append(args, itemArgs...)
+	}
+
+	if len(items) == 0 {
+		return "", nil
 	}
 
-	return orderByClause
+	return " ORDER BY " + strings.Join(items, ", "), args
+}
+
+func (q *queryBuilder) makeGroupByClause() string {
+	var groupByClause string
+
+	if len(q.groupBy) > 0 {
+		groupByClause = " GROUP BY " + strings.Join(q.groupBy, ", ")
+	}
+
+	return groupByClause
+}
+
+func (q *queryBuilder) makeHavingClause() string {
+	var havingClause string
+
+	if q.havingClause != "" {
+		havingClause = " HAVING " + strings.TrimPrefix(q.havingClause, " AND ")
+	}
+
+	return havingClause
 }
 
 func (q *queryBuilder) makeLimitClause() string {
 	offset := (q.page - 1) * q.limit
 	limitClause := fmt.Sprintf(" LIMIT %d, %d", offset, q.limit+q.extraLimit)
+	if q.dialect == Postgres {
+		limitClause = fmt.Sprintf(" LIMIT %d OFFSET %d", q.limit+q.extraLimit, offset)
+	}
 
 	return limitClause
 }
@@ -150,57 +404,145 @@ func (q *queryBuilder) appendCustomWhere() {
 	q.args = append(q.args, q.customWhereClauseArgs...)
 }
 
+func (q *queryBuilder) appendCustomHaving() {
+	for _, hc := range q.customHavingClause {
+		q.havingClause += " AND " + hc
+	}
+	q.havingArgs = append(q.havingArgs, q.customHavingClauseArgs...)
+}
+
+// isGrouped report whether the query has GROUP BY or HAVING clause.
+func (q *queryBuilder) isGrouped() bool {
+	return len(q.groupBy) > 0 || q.havingClause != "" || len(q.customHavingClause) > 0
+}
+
+// allArgs return the where and having args, followed by the extra args (e.g: order by args).
+func (q *queryBuilder) allArgs(extra ...interface{}) []interface{} {
+	if len(q.havingArgs) == 0 && len(extra) == 0 {
+		return q.args
+	}
+
+	args := append(append([]interface{}{}, q.args...), q.havingArgs...)
+	return append(args, extra...)
+}
+
 func (q *queryBuilder) Build(param interface{}) (sqlClause string, args []interface{}, err error) {
 	if err = q.build(param); err != nil {
 		return
 	}
 
-	sqlClause = q.whereClause + q.makeOrderByClause() + q.makeLimitClause()
+	orderByClause, orderByArgs := q.makeOrderByClause()
+	sqlClause = q.whereClause + q.makeGroupByClause() + q.makeHavingClause() + orderByClause + q.makeLimitClause()
+	args = q.allArgs(orderByArgs...)
 
 	fmt.Println("[qbuilder] clause: ", sqlClause)
-	fmt.Println("[qbuilder] args: ", q.args)
+	fmt.Println("[qbuilder] args: ", args)
 
-	return sqlClause, q.args, nil
+	return sqlClause, args, nil
 }
 
+// BuildCount return the clause for the count query, should be called after Build.
+//
+// For grouped query (GroupBy or agg tag), `SELECT COUNT(*) FROM t` + clause would return one row per group,
+// so it return ErrInvalidParam, use BuildCountQuery instead.
 func (q *queryBuilder) BuildCount() (sqlClause string, args []interface{}, err error) {
-	sqlClause = q.whereClause + q.makeOrderByClause()
+	if q.isGrouped() {
+		return "", nil, fmt.Errorf("%w: grouped query cannot be counted with BuildCount, use BuildCountQuery", ErrInvalidParam)
+	}
+
+	orderByClause, orderByArgs := q.makeOrderByClause()
+	sqlClause = q.whereClause + orderByClause
+	args = q.allArgs(orderByArgs...)
 
 	fmt.Println("[qbuilder] clauseCount: ", sqlClause)
-	fmt.Println("[qbuilder] argsCount: ", q.args)
+	fmt.Println("[qbuilder] argsCount: ", args)
+
+	return sqlClause, args, nil
+}
+
+// BuildCountQuery wrap the select query into a count query, should be called after Build.
+//
+// e.g: SELECT COUNT(*) FROM (SELECT merchant_id FROM trx WHERE 1=1 GROUP BY merchant_id) AS qbuilder_count
+func (q *queryBuilder) BuildCountQuery(selectQuery string) (query string, args []interface{}, err error) {
+	query = fmt.Sprintf(countQueryFmt, selectQuery, q.whereClause+q.makeGroupByClause()+q.makeHavingClause())
+	args = q.allArgs()
 
-	return sqlClause, q.args, nil
+	return query, args, nil
 }
 
 func (q *queryBuilder) build(param interface{}) error {
 	p := reflect.ValueOf(param)
 	if p.Kind() != reflect.Ptr || p.IsNil() {
-		return errors.New("should be a pointer and cannot be nil")
+		return fmt.Errorf("%w: should be a pointer and cannot be nil", ErrInvalidParam)
 	}
 
-	val := reflect.ValueOf(param).Elem()
+	if p.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: should be a pointer to struct, got %s", ErrInvalidParam, p.Type())
+	}
+
+	if err := compileParamType(p.Elem().Type()); err != nil {
+		return err
+	}
+
+	var (
+		val                   = reflect.ValueOf(param).Elem()
+		pageField, limitField string
+		sortField             reflect.StructField
+		err                   error
+	)
+
+	q.now = q.clock()
+	if q.location != nil {
+		q.now = q.now.In(q.location)
+	}
 
 	for i := 0; i < val.NumField(); i++ {
 		field := val.Field(i)
-		structTags := val.Type().Field(i).Tag    // param:"created_at__gte" db:"created_at"
-		tagParam := structTags.Get("param")      // created_at__lte
-		tagDB := structTags.Get("db")            // created_at
-		tagJsonKey := structTags.Get("json_key") // $.a.b
+		sf := val.Type().Field(i)
+		structTags := sf.Tag // param:"created_at__gte" db:"created_at"
+
+		c := newCursor(field, structTags, q)
+		tagParam := c.param
 
-		c := newCursor(field, tagParam, tagDB, tagJsonKey)
+		if tagParam != "" && tagParam != "-" && !sf.IsExported() {
+			return newFieldError(ErrInvalidParam, sf.Name, tagParam, "unexported field cannot be used as param")
+		}
 
 		if c.IsPage() {
+			if q.pageMax, err = parseLimitTag(structTags.Get("max"), q.pageMax); err != nil {
+				return newFieldError(ErrInvalidParam, sf.Name, tagParam, "tag max %v", err)
+			}
 			q.page = q.handleParamPage(field)
+			pageField = sf.Name
 			continue
 		}
 
 		if c.IsLimit() {
+			if q.limitMax, err = parseLimitTag(structTags.Get("max"), q.limitMax); err != nil {
+				return newFieldError(ErrInvalidParam, sf.Name, tagParam, "tag max %v", err)
+			}
+			if q.limitDefault, err = parseLimitTag(structTags.Get("default"), q.limitDefault); err != nil {
+				return newFieldError(ErrInvalidParam, sf.Name, tagParam, "tag default %v", err)
+			}
 			q.limit = q.handleParamLimit(field)
+			limitField = sf.Name
 			continue
 		}
 
 		if c.IsSortBy() {
 			q.sortBy = q.handleParamShortBy(field)
+			sortField = sf
+			if tag := structTags.Get("tiebreaker"); tag != "" {
+				q.tieBreaker = tag
+			}
+			if tag := structTags.Get("default"); tag != "" {
+				q.defaultSort = normalizeSort([]string{tag})
+			}
+			continue
+		}
+
+		if c.IsSoftDeleteScope() {
+			q.handleParamSoftDelete(c)
 			continue
 		}
 
@@ -208,27 +550,87 @@ func (q *queryBuilder) build(param interface{}) error {
 			continue
 		}
 
+		if reason := c.CheckInList(); reason != "" {
+			return newFieldError(ErrTooManyValues, sf.Name, tagParam, "%s", reason)
+		}
+
+		if val, ok := c.StringValue(); ok && q.regexValidation && c.IsRegex() {
+			if _, err := regexp.Compile(val); err != nil {
+				return newFieldError(ErrInvalidParam, sf.Name, tagParam, "invalid regex: %v", err)
+			}
+		}
+
+		if c.IsRequired() && c.IsZero() {
+			return newFieldError(ErrMissingParam, sf.Name, tagParam, "")
+		}
+
+		if c.IsEmptySlice() {
+			policy, err := c.EmptySlicePolicy()
+			if err != nil {
+				return newFieldError(ErrInvalidParam, sf.Name, tagParam, "%v", err)
+			}
+
+			switch {
+			case policy == EmptyError:
+				return newFieldError(ErrEmptySlice, sf.Name, tagParam, "")
+			case policy == EmptyNone && !c.IsExclusive():
+				q.whereClause += " AND 1=0"
+				q.filterCount++
+			}
+			continue
+		}
+
 		clause, args, skip := c.Make()
+		if c.err != nil {
+			return newFieldError(ErrInvalidParam, sf.Name, tagParam, "%v", c.err)
+		}
+		skip = skip || c.IsOmitEmpty() && c.IsZero()
+
+		if key := structTags.Get("sort_key"); key != "" {
+			if sk, ok := c.DerivedSortKey(args, skip); ok {
+				q.addSortKey(key, sk)
+			}
+		}
+
 		if skip {
 			continue
 		}
+
+		if c.IsAggregate() {
+			q.havingClause += clause
+			q.havingArgs = append(q.havingArgs, args...)
+			continue
+		}
+
 		q.whereClause += clause
 		q.args = append(q.args, args...)
+		q.filterCount++
+	}
+
+	if v, ok := q.checkSortable(q.sortBy, sortField.Tag.Get("sortable")); !ok {
+		name, _ := parseTag(sortField.Tag.Get("param"))
+		return newFieldError(ErrSortFieldNotAllowed, sortField.Name, name, "%q is not sortable", v)
 	}
 
-	// custom where
+	page, limit, exceeded := q.validatePageAndLimit(q.page, q.limit)
+	if exceeded != "" {
+		if strings.HasPrefix(exceeded, "limit") {
+			return newFieldError(ErrLimitExceeded, limitField, "limit", "%s", exceeded)
+		}
+		return newFieldError(ErrLimitExceeded, pageField, "page", "%s", exceeded)
+	}
+	q.page, q.limit = page, limit
+
+	// custom where & having
 	q.appendCustomWhere()
+	q.appendCustomHaving()
+	q.appendSoftDelete()
 
 	return nil
 }
 
+// ValidatePageAndLimit apply the default page and limit, use the queryBuilder method to apply the options.
 func ValidatePageAndLimit(p, l int64) (page int64, limit int64) {
-	page, limit = p, l
-	if page == 0 {
-		page = 1
-	}
-	if limit == 0 {
-		limit = 10
-	}
+	page, limit, _ = New().ValidatePageAndLimit(p, l)
 	return page, limit
 }
@@ -71,6 +71,93 @@ type ParamJsonSearch struct {
 	JsonArrObj sql.NullString `param:"jsonArrObj" db:"json_arr_obj" json_key:"$[*].a"` // search array of object
 }
 
+type ParamAggregate struct {
+	MerchantID sql.NullInt64   `param:"merchant_id" db:"merchant_id"`
+	TotalGTE   sql.NullFloat64 `param:"total__gte" db:"amount" agg:"sum"`
+	CountGT    sql.NullInt64   `param:"count__gt" db:"id" agg:"count"`
+}
+
+type ParamInvalidAggregate struct {
+	Total sql.NullFloat64 `param:"total" db:"amount" agg:"median"`
+}
+
+type ParamSortBy struct {
+	SortBy []string `param:"sort_by"`
+}
+
+type ParamSortString struct {
+	Sort string `param:"sort"`
+}
+
+type ParamSearch struct {
+	Q    sql.NullString `param:"q__search" db:"title" fulltext:"title,description" sort_key:"relevance"`
+	Name string         `param:"name__search" db:"name"`
+	Sort string         `param:"sort"`
+}
+
+type ParamStringMatch struct {
+	Regex  string         `param:"name__regex" db:"name"`
+	IRegex sql.NullString `param:"code__iregex" db:"code"`
+	IExact string         `param:"email__iexact" db:"email"`
+}
+
+type ParamSliceOperator struct {
+	NameLike   []string `param:"name__like" db:"name"`
+	TagOverlap []string `param:"tags__overlap" db:"tags"`
+	TagAll     []string `param:"tags__contains_all" db:"tags"`
+	IDAny      []int64  `param:"id__any" db:"id"`
+}
+
+type ParamInvalidSliceOperator struct {
+	IDs []int64 `param:"id__gte" db:"id"`
+}
+
+type ParamInvalidScalarOperator struct {
+	Status string `param:"status__nin" db:"status"`
+}
+
+type ParamSortTag struct {
+	ShortBy []string `param:"short_by" tiebreaker:"id" default:"-created_at"`
+}
+
+type ParamSortTagDirection struct {
+	ShortBy []string `param:"short_by" default:"created_at:desc, name"`
+}
+
+type ParamMoreTypes struct {
+	Active   bool            `param:"active" db:"active"`
+	Deleted  *bool           `param:"deleted" db:"deleted"`
+	Name     *string         `param:"name" db:"name"`
+	Level    int8            `param:"level" db:"level"`
+	Rank     int16           `param:"rank__lte" db:"rank"`
+	Stock    uint            `param:"stock__gt" db:"stock"`
+	ShopID   *uint64         `param:"shop_id" db:"shop_id"`
+	Timeout  time.Duration   `param:"timeout__gte" db:"timeout_ms" unit:"ms"`
+	Flags    []bool          `param:"flags" db:"flags"`
+	Sizes    []uint16        `param:"sizes" db:"sizes"`
+	Windows  []time.Duration `param:"window" db:"window_s" unit:"s"`
+	Duration *time.Duration  `param:"duration" db:"duration"`
+}
+
+type ParamZeroValue struct {
+	Name   string `param:"name" db:"name"`
+	Age    int    `param:"age,omitempty" db:"age"`
+	Score  int64  `param:"score__gte" db:"score"`
+	ShopID *int64 `param:"shop_id,omitempty" db:"shop_id"`
+}
+
+type ParamRequired struct {
+	ShopID int64 `param:"shop_id,required" db:"shop_id"`
+}
+
+type ParamInvalidZeroTag struct {
+	ShopID int64 `param:"shop_id,omitempty,required" db:"shop_id"`
+}
+
+type ParamInvalidUnit struct {
+	Timeout time.Duration `param:"timeout" db:"timeout" unit:"day"`
+}
+
 func Test_QBuilder_SkipField(t *testing.T) {
 	param := ParamSkip{
 		String: "test",
@@ -455,3 +542,535 @@ func Test_QBuilder_JsonSearch(t *testing.T) {
 	assert.Equal(t, expClause, clause)
 	assert.Equal(t, expArgs, args)
 }
+
+func Test_QBuilder_GroupBy(t *testing.T) {
+	testCase := []struct {
+		desc      string
+		param     ParamAggregate
+		groupBy   []string
+		expClause string
+		expArgs   []interface{}
+	}{
+		{
+			desc:      "group by without having",
+			param:     ParamAggregate{},
+			groupBy:   []string{"merchant_id"},
+			expClause: " WHERE 1=1 GROUP BY merchant_id LIMIT 0, 10",
+			expArgs:   nil,
+		},
+		{
+			desc: "group by with having",
+			param: ParamAggregate{
+				MerchantID: sql.NullInt64{Valid: true, Int64: 1},
+				TotalGTE:   sql.NullFloat64{Valid: true, Float64: 100},
+				CountGT:    sql.NullInt64{Valid: true, Int64: 5},
+			},
+			groupBy:   []string{"merchant_id", "status"},
+			expClause: " WHERE 1=1 AND merchant_id = ? GROUP BY merchant_id, status HAVING SUM(amount) >= ? AND COUNT(id) > ? LIMIT 0, 10",
+			expArgs:   []interface{}{int64(1), float64(100), int64(5)},
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New().GroupBy(tc.groupBy...).Build(&tc.param)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+
+	t.Run("custom having clause", func(t *testing.T) {
+		param := ParamAggregate{
+			TotalGTE: sql.NullFloat64{Valid: true, Float64: 100},
+		}
+
+		qb := New().GroupBy("merchant_id")
+		qb.AddWhereClause("status = ?", "PAID")
+		qb.AddHavingClause("MAX(amount) < ?", 500)
+		clause, args, err := qb.Build(&param)
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND status = ? GROUP BY merchant_id HAVING SUM(amount) >= ? AND MAX(amount) < ? LIMIT 0, 10", clause)
+		assert.Equal(t, []interface{}{"PAID", float64(100), 500}, args)
+	})
+
+	t.Run("count grouped query", func(t *testing.T) {
+		param := ParamAggregate{
+			TotalGTE: sql.NullFloat64{Valid: true, Float64: 100},
+		}
+
+		qb := New()
+		_, _, err := qb.Build(&param)
+		assert.Nil(t, err)
+
+		_, _, err = qb.BuildCount()
+		assert.ErrorIs(t, err, ErrInvalidParam)
+
+		_, _, err = New().GroupBy("merchant_id").BuildCount()
+		assert.ErrorIs(t, err, ErrInvalidParam)
+	})
+
+	t.Run("invalid aggregate", func(t *testing.T) {
+		param := ParamInvalidAggregate{}
+		_, _, err := New().Build(&param)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_QBuilder_BuildCountQuery(t *testing.T) {
+	param := ParamAggregate{
+		MerchantID: sql.NullInt64{Valid: true, Int64: 1},
+		TotalGTE:   sql.NullFloat64{Valid: true, Float64: 100},
+	}
+
+	qb := New().GroupBy("merchant_id")
+	_, _, err := qb.Build(&param)
+	assert.Nil(t, err)
+
+	query, args, err := qb.BuildCountQuery("SELECT merchant_id FROM trx")
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM (SELECT merchant_id FROM trx WHERE 1=1 AND merchant_id = ? GROUP BY merchant_id HAVING SUM(amount) >= ?) AS qbuilder_count", query)
+	assert.Equal(t, []interface{}{int64(1), float64(100)}, args)
+}
+
+func Test_QBuilder_NotStructParam(t *testing.T) {
+	p := 10
+	_, _, err := New().Build(&p)
+	assert.NotNil(t, err)
+}
+
+func Test_QBuilder_TieBreaker(t *testing.T) {
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		param     interface{}
+		expClause string
+	}{
+		{
+			desc:      "follow the last sort direction",
+			opt:       []Option{WithTieBreaker("id")},
+			param:     &ParamPaginationInt64{ShortBy: []string{"status", "-created_at"}},
+			expClause: " WHERE 1=1 ORDER BY status ASC, created_at DESC, id DESC LIMIT 0, 10",
+		},
+		{
+			desc:      "already present",
+			opt:       []Option{WithTieBreaker("id")},
+			param:     &ParamPaginationInt64{ShortBy: []string{"-id", "status"}},
+			expClause: " WHERE 1=1 ORDER BY id DESC, status ASC LIMIT 0, 10",
+		},
+		{
+			desc:      "empty sort",
+			opt:       []Option{WithTieBreaker("id")},
+			param:     &ParamPaginationInt64{},
+			expClause: " WHERE 1=1 ORDER BY id ASC LIMIT 0, 10",
+		},
+		{
+			desc:      "default sort",
+			opt:       []Option{WithTieBreaker("id"), WithDefaultSort("-created_at")},
+			param:     &ParamPaginationInt64{},
+			expClause: " WHERE 1=1 ORDER BY created_at DESC, id DESC LIMIT 0, 10",
+		},
+		{
+			desc:      "default sort is replaced by short_by",
+			opt:       []Option{WithDefaultSort("-created_at")},
+			param:     &ParamPaginationInt64{ShortBy: []string{"status"}},
+			expClause: " WHERE 1=1 ORDER BY status ASC LIMIT 0, 10",
+		},
+		{
+			desc:      "tag",
+			param:     &ParamSortTag{},
+			expClause: " WHERE 1=1 ORDER BY created_at DESC, id DESC LIMIT 0, 10",
+		},
+		{
+			desc:      "default sort with direction",
+			opt:       []Option{WithDefaultSort("created_at:desc", "name:asc")},
+			param:     &ParamPaginationInt64{},
+			expClause: " WHERE 1=1 ORDER BY created_at DESC, name ASC LIMIT 0, 10",
+		},
+		{
+			desc:      "tag with direction",
+			param:     &ParamSortTagDirection{},
+			expClause: " WHERE 1=1 ORDER BY created_at DESC, name ASC LIMIT 0, 10",
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, _, err := New(tc.opt...).Build(tc.param)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+		})
+	}
+}
+
+func Test_QBuilder_SortBy(t *testing.T) {
+	testCase := []struct {
+		desc      string
+		param     interface{}
+		expClause string
+	}{
+		{
+			desc:      "sort_by",
+			param:     &ParamSortBy{SortBy: []string{"-created_at", "name:asc", "status:DESC"}},
+			expClause: " WHERE 1=1 ORDER BY created_at DESC, name ASC, status DESC LIMIT 0, 10",
+		},
+		{
+			desc:      "sort_by with comma separated item",
+			param:     &ParamSortBy{SortBy: []string{"-created_at,name"}},
+			expClause: " WHERE 1=1 ORDER BY created_at DESC, name ASC LIMIT 0, 10",
+		},
+		{
+			desc:      "comma separated sort",
+			param:     &ParamSortString{Sort: "-created_at, name:desc,,id:asc"},
+			expClause: " WHERE 1=1 ORDER BY created_at DESC, name DESC, id ASC LIMIT 0, 10",
+		},
+		{
+			desc:      "empty sort",
+			param:     &ParamSortString{},
+			expClause: " WHERE 1=1 LIMIT 0, 10",
+		},
+		{
+			desc:      "short_by",
+			param:     &ParamPaginationInt64{ShortBy: []string{"name:desc"}},
+			expClause: " WHERE 1=1 ORDER BY name DESC LIMIT 0, 10",
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, _, err := New().Build(tc.param)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+		})
+	}
+}
+
+func Test_QBuilder_Search(t *testing.T) {
+	testCase := []struct {
+		desc      string
+		dialect   Dialect
+		param     ParamSearch
+		expClause string
+		expArgs   []interface{}
+	}{
+		{
+			desc: "mysql",
+			param: ParamSearch{
+				Q:    sql.NullString{Valid: true, String: "coffee"},
+				Name: "latte",
+			},
+			expClause: " WHERE 1=1 AND MATCH(title, description) AGAINST (? IN BOOLEAN MODE) AND MATCH(name) AGAINST (? IN BOOLEAN MODE) LIMIT 0, 10",
+			expArgs:   []interface{}{"coffee", "latte"},
+		},
+		{
+			desc:    "postgres",
+			dialect: Postgres,
+			param: ParamSearch{
+				Q:    sql.NullString{Valid: true, String: "coffee"},
+				Name: "latte",
+			},
+			expClause: " WHERE 1=1 AND to_tsvector(coalesce(title, '') || ' ' || coalesce(description, '')) @@ plainto_tsquery(?) AND to_tsvector(name) @@ plainto_tsquery(?) LIMIT 10 OFFSET 0",
+			expArgs:   []interface{}{"coffee", "latte"},
+		},
+		{
+			desc: "mysql relevance",
+			param: ParamSearch{
+				Q:    sql.NullString{Valid: true, String: "coffee"},
+				Sort: "-relevance,id",
+			},
+			expClause: " WHERE 1=1 AND MATCH(title, description) AGAINST (? IN BOOLEAN MODE) ORDER BY MATCH(title, description) AGAINST (? IN BOOLEAN MODE) DESC, id ASC LIMIT 0, 10",
+			expArgs:   []interface{}{"coffee", "coffee"},
+		},
+		{
+			desc:    "postgres relevance",
+			dialect: Postgres,
+			param: ParamSearch{
+				Q:    sql.NullString{Valid: true, String: "coffee"},
+				Sort: "-relevance",
+			},
+			expClause: " WHERE 1=1 AND to_tsvector(coalesce(title, '') || ' ' || coalesce(description, '')) @@ plainto_tsquery(?) ORDER BY ts_rank(to_tsvector(coalesce(title, '') || ' ' || coalesce(description, '')), plainto_tsquery(?)) DESC LIMIT 10 OFFSET 0",
+			expArgs:   []interface{}{"coffee", "coffee"},
+		},
+		{
+			desc: "relevance without keyword",
+			param: ParamSearch{
+				Sort: "-relevance,id",
+			},
+			expClause: " WHERE 1=1 ORDER BY id ASC LIMIT 0, 10",
+			expArgs:   nil,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(WithDialect(tc.dialect)).Build(&tc.param)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+}
+
+func Test_QBuilder_StringMatch(t *testing.T) {
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		param     ParamStringMatch
+		expClause string
+		expArgs   []interface{}
+		expErr    error
+	}{
+		{
+			desc: "mysql",
+			param: ParamStringMatch{
+				Regex:  "^foo",
+				IRegex: sql.NullString{Valid: true, String: "bar$"},
+				IExact: "Foo@Example.com",
+			},
+			expClause: " WHERE 1=1 AND name REGEXP ? AND REGEXP_LIKE(code, ?, 'i') AND LOWER(email) = LOWER(?) LIMIT 0, 10",
+			expArgs:   []interface{}{"^foo", "bar$", "Foo@Example.com"},
+		},
+		{
+			desc: "postgres",
+			opt:  []Option{WithDialect(Postgres)},
+			param: ParamStringMatch{
+				Regex:  "^foo",
+				IRegex: sql.NullString{Valid: true, String: "bar$"},
+				IExact: "foo_bar%@example.com",
+			},
+			expClause: " WHERE 1=1 AND name ~ ? AND code ~* ? AND email ILIKE ? LIMIT 10 OFFSET 0",
+			expArgs:   []interface{}{"^foo", "bar$", `foo\_bar\%@example.com`},
+		},
+		{
+			desc:      "invalid regex without validation",
+			param:     ParamStringMatch{Regex: "(foo"},
+			expClause: " WHERE 1=1 AND name REGEXP ? LIMIT 0, 10",
+			expArgs:   []interface{}{"(foo"},
+		},
+		{
+			desc:   "invalid regex with validation",
+			opt:    []Option{WithRegexValidation()},
+			param:  ParamStringMatch{IRegex: sql.NullString{Valid: true, String: "(foo"}},
+			expErr: ErrInvalidParam,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(tc.opt...).Build(&tc.param)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+}
+
+func Test_QBuilder_SliceOperator(t *testing.T) {
+	param := ParamSliceOperator{
+		NameLike:   []string{"foo%", "%bar"},
+		TagOverlap: []string{"a", "b"},
+		TagAll:     []string{"c"},
+		IDAny:      []int64{1, 2},
+	}
+
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		expClause string
+		expArgs   []interface{}
+	}{
+		{
+			desc:      "mysql",
+			expClause: ` WHERE 1=1 AND (name LIKE ? OR name LIKE ?) AND JSON_OVERLAPS(tags, ?) AND JSON_CONTAINS(tags, ?) AND id IN (?, ?) LIMIT 0, 10`,
+			expArgs:   []interface{}{"foo%", "%bar", `["a","b"]`, `["c"]`, int64(1), int64(2)},
+		},
+		{
+			desc:      "postgres",
+			opt:       []Option{WithDialect(Postgres)},
+			expClause: ` WHERE 1=1 AND (name LIKE ? OR name LIKE ?) AND EXISTS (SELECT 1 FROM jsonb_array_elements(tags) AS e WHERE ?::jsonb @> e) AND tags @> ?::jsonb AND id = ANY(?) LIMIT 10 OFFSET 0`,
+			expArgs:   []interface{}{"foo%", "%bar", `["a","b"]`, `["c"]`, []int64{1, 2}},
+		},
+		{
+			desc: "postgres with array binder",
+			opt: []Option{WithDialect(Postgres), WithArrayBinder(func(v interface{}) interface{} {
+				return fmt.Sprint(v)
+			})},
+			expClause: ` WHERE 1=1 AND (name LIKE ? OR name LIKE ?) AND EXISTS (SELECT 1 FROM jsonb_array_elements(tags) AS e WHERE ?::jsonb @> e) AND tags @> ?::jsonb AND id = ANY(?) LIMIT 10 OFFSET 0`,
+			expArgs:   []interface{}{"foo%", "%bar", `["a","b"]`, `["c"]`, "[1 2]"},
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(tc.opt...).Build(&param)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+
+	t.Run("empty slices", func(t *testing.T) {
+		clause, args, err := New(WithDialect(Postgres)).Build(&ParamSliceOperator{IDAny: []int64{}, TagAll: []string{}})
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 LIMIT 10 OFFSET 0", clause)
+		assert.Nil(t, args)
+	})
+
+	t.Run("scalar operator with slice", func(t *testing.T) {
+		_, _, err := New().Build(&ParamInvalidSliceOperator{IDs: []int64{1}})
+		assert.ErrorIs(t, err, ErrInvalidOperator)
+
+		_, err = For[ParamInvalidSliceOperator]()
+		assert.ErrorIs(t, err, ErrInvalidOperator)
+	})
+
+	t.Run("slice operator with scalar", func(t *testing.T) {
+		_, _, err := New().Build(&ParamInvalidScalarOperator{Status: "ACTIVE"})
+		assert.ErrorIs(t, err, ErrInvalidOperator)
+	})
+}
+
+func Test_QBuilder_MoreTypes(t *testing.T) {
+	deleted, name, shopID, duration := false, "", uint64(18446744073709551615), time.Minute
+
+	testCase := []struct {
+		desc      string
+		param     interface{}
+		expClause string
+		expArgs   []interface{}
+		expErr    error
+	}{
+		{
+			desc:      "zero values",
+			param:     &ParamMoreTypes{},
+			expClause: " WHERE 1=1 AND level = ? AND rank <= ? AND stock > ? AND timeout_ms >= ? LIMIT 0, 10",
+			expArgs:   []interface{}{int8(0), int16(0), uint(0), int64(0)},
+		},
+		{
+			desc: "set values",
+			param: &ParamMoreTypes{
+				Active:   true,
+				Deleted:  &deleted,
+				Name:     &name,
+				Level:    1,
+				Rank:     2,
+				Stock:    3,
+				ShopID:   &shopID,
+				Timeout:  1500 * time.Millisecond,
+				Flags:    []bool{true, false},
+				Sizes:    []uint16{4, 5},
+				Windows:  []time.Duration{time.Minute, time.Hour},
+				Duration: &duration,
+			},
+			expClause: " WHERE 1=1 AND active = ? AND deleted = ? AND name LIKE ? AND level = ? AND rank <= ? AND stock > ? AND shop_id = ? AND timeout_ms >= ?" +
+				" AND flags IN (?, ?) AND sizes IN (?, ?) AND window_s IN (?, ?) AND duration = ? LIMIT 0, 10",
+			expArgs: []interface{}{true, false, "", int8(1), int16(2), uint(3), shopID, int64(1500),
+				true, false, uint16(4), uint16(5), int64(60), int64(3600), int64(time.Minute)},
+		},
+		{
+			desc:   "invalid unit",
+			param:  &ParamInvalidUnit{},
+			expErr: ErrInvalidParam,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New().Build(tc.param)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+
+	t.Run("validated on construction", func(t *testing.T) {
+		_, err := For[ParamMoreTypes]()
+		assert.Nil(t, err)
+
+		_, err = For[ParamInvalidUnit]()
+		assert.ErrorIs(t, err, ErrInvalidParam)
+	})
+}
+
+func Test_QBuilder_ZeroValue(t *testing.T) {
+	zero := int64(0)
+
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		param     interface{}
+		expClause string
+		expArgs   []interface{}
+		expErr    error
+	}{
+		{
+			desc:      "omitempty tag",
+			param:     &ParamZeroValue{},
+			expClause: " WHERE 1=1 AND score >= ? LIMIT 0, 10",
+			expArgs:   []interface{}{int64(0)},
+		},
+		{
+			desc:      "omitempty tag with values",
+			param:     &ParamZeroValue{Age: 20, ShopID: &zero},
+			expClause: " WHERE 1=1 AND age = ? AND score >= ? AND shop_id = ? LIMIT 0, 10",
+			expArgs:   []interface{}{20, int64(0), int64(0)},
+		},
+		{
+			desc:      "builder omit empty",
+			opt:       []Option{WithOmitEmpty()},
+			param:     &ParamZeroValue{ShopID: &zero},
+			expClause: " WHERE 1=1 AND shop_id = ? LIMIT 0, 10",
+			expArgs:   []interface{}{int64(0)},
+		},
+		{
+			desc:      "builder omit empty primitive",
+			opt:       []Option{WithOmitEmpty()},
+			param:     &ParamPrimitive{Int: 10},
+			expClause: " WHERE 1=1 AND int = ? LIMIT 0, 10",
+			expArgs:   []interface{}{10},
+		},
+		{
+			desc:      "required",
+			param:     &ParamRequired{ShopID: 10},
+			expClause: " WHERE 1=1 AND shop_id = ? LIMIT 0, 10",
+			expArgs:   []interface{}{int64(10)},
+		},
+		{
+			desc:   "required missing",
+			param:  &ParamRequired{},
+			expErr: ErrMissingParam,
+		},
+		{
+			desc:   "required missing with omit empty",
+			opt:    []Option{WithOmitEmpty()},
+			param:  &ParamRequired{},
+			expErr: ErrMissingParam,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(tc.opt...).Build(tc.param)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+
+	t.Run("invalid tag on construction", func(t *testing.T) {
+		_, err := For[ParamInvalidZeroTag]()
+		assert.ErrorIs(t, err, ErrInvalidParam)
+	})
+}
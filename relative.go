@@ -0,0 +1,189 @@
+package qbuilder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// layouts of the absolute time, the layouts without zone are parsed in the clock location
+var relativeTimeLayouts = []struct {
+	layout string
+	local  bool
+}{
+	{time.RFC3339Nano, false},
+	{"2006-01-02 15:04:05", true},
+	{"2006-01-02T15:04:05", true},
+	{"2006-01-02", true},
+}
+
+// supported anchors of the relative time, the week starts on Monday
+var relativeAnchors = map[string]func(now time.Time) time.Time{
+	"now": func(now time.Time) time.Time {
+		return now
+	},
+	"today": func(now time.Time) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	},
+	"yesterday": func(now time.Time) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location())
+	},
+	"tomorrow": func(now time.Time) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	},
+	"start_of_week": func(now time.Time) time.Time {
+		offset := (int(now.Weekday()) + 6) % 7
+		return time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, now.Location())
+	},
+	"start_of_month": func(now time.Time) time.Time {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	},
+	"start_of_year": func(now time.Time) time.Time {
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	},
+}
+
+// RelativeTime is an absolute or relative time expression, resolved against the clock at build time.
+//
+// e.g: now-7d, today, yesterday, start_of_week, start_of_month+1M-1d, 2024-01-31, 2024-01-31T10:00:00+07:00
+//
+// The offset units are s (second), m (minute), h (hour), d (day), w (week), M (month) and y (year).
+// It implements encoding.TextUnmarshaler, so it can be decoded from JSON or a query string by most binders.
+type RelativeTime struct {
+	expr    string
+	abs     time.Time
+	local   bool // abs has no zone, it's resolved in the clock location
+	anchor  string
+	offsets []relativeOffset
+}
+
+type relativeOffset struct {
+	n    int
+	unit byte
+}
+
+// ParseRelativeTime parse the time expression, an empty string is the zero RelativeTime.
+func ParseRelativeTime(s string) (RelativeTime, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return RelativeTime{}, nil
+	}
+
+	for _, l := range relativeTimeLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return RelativeTime{expr: s, abs: t, local: l.local}, nil
+		}
+	}
+
+	anchor, rest := s, ""
+	if i := strings.IndexAny(s, "+-"); i >= 0 {
+		anchor, rest = s[:i], s[i:]
+	}
+	if _, ok := relativeAnchors[anchor]; !ok {
+		return RelativeTime{}, fmt.Errorf("%w: unknown relative time %q", ErrInvalidParam, s)
+	}
+
+	r := RelativeTime{expr: s, anchor: anchor}
+	for rest != "" {
+		i := 1
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if rest[0] != '+' && rest[0] != '-' || i == 1 || i == len(rest) || !strings.ContainsRune("smhdwMy", rune(rest[i])) {
+			return RelativeTime{}, fmt.Errorf("%w: invalid offset %q of relative time %q", ErrInvalidParam, rest, s)
+		}
+
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return RelativeTime{}, fmt.Errorf("%w: invalid offset %q of relative time %q", ErrInvalidParam, rest, s)
+		}
+
+		r.offsets = append(r.offsets, relativeOffset{n: n, unit: rest[i]})
+		rest = rest[i+1:]
+	}
+
+	return r, nil
+}
+
+// MustParseRelativeTime is like ParseRelativeTime but panics if the expression is invalid.
+func MustParseRelativeTime(s string) RelativeTime {
+	r, err := ParseRelativeTime(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Resolve return the time relative to now.
+func (r RelativeTime) Resolve(now time.Time) time.Time {
+	if r.anchor == "" {
+		if r.local && !r.abs.IsZero() {
+			return time.Date(r.abs.Year(), r.abs.Month(), r.abs.Day(),
+				r.abs.Hour(), r.abs.Minute(), r.abs.Second(), r.abs.Nanosecond(), now.Location())
+		}
+		return r.abs
+	}
+
+	t := relativeAnchors[r.anchor](now)
+	for _, o := range r.offsets {
+		switch o.unit {
+		case 's':
+			t = t.Add(time.Duration(o.n) * time.Second)
+		case 'm':
+			t = t.Add(time.Duration(o.n) * time.Minute)
+		case 'h':
+			t = t.Add(time.Duration(o.n) * time.Hour)
+		case 'd':
+			t = t.AddDate(0, 0, o.n)
+		case 'w':
+			t = t.AddDate(0, 0, 7*o.n)
+		case 'M':
+			t = t.AddDate(0, o.n, 0)
+		case 'y':
+			t = t.AddDate(o.n, 0, 0)
+		}
+	}
+
+	return t
+}
+
+// IsZero report whether the expression is empty.
+func (r RelativeTime) IsZero() bool {
+	return r.expr == ""
+}
+
+func (r RelativeTime) String() string {
+	return r.expr
+}
+
+func (r RelativeTime) MarshalText() ([]byte, error) {
+	return []byte(r.expr), nil
+}
+
+func (r *RelativeTime) UnmarshalText(b []byte) error {
+	parsed, err := ParseRelativeTime(string(b))
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+	return nil
+}
+
+// WithClock set the clock to resolve RelativeTime, default is time.Now.
+// The clock is read once per build, so every relative time of a query is resolved against the same instant.
+func WithClock(now func() time.Time) Option {
+	return func(qb *queryBuilder) {
+		qb.clock = now
+	}
+}
+
+func (c *cursor) makeClauseRelativeTime(layout, operand string, val RelativeTime) (clause string, args []interface{}, skip bool) {
+	if val.IsZero() {
+		skip = true
+		return
+	}
+
+	return c.makeClauseTimeValue(layout, operand, val.Resolve(c.qb.now))
+}
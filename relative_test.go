@@ -0,0 +1,103 @@
+package qbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamRelativeTime struct {
+	CreatedAtGTE RelativeTime  `param:"created_at__gte" db:"created_at"`
+	CreatedAtLTE *RelativeTime `param:"created_at__lte" db:"created_at" trunc:"day"`
+}
+
+func Test_RelativeTime_Resolve(t *testing.T) {
+	utc7 := time.FixedZone("UTC+7", 7*60*60)
+	now := time.Date(2024, 3, 14, 10, 30, 0, 0, utc7) // Thursday
+
+	testCase := []struct {
+		expr   string
+		exp    time.Time
+		expErr error
+	}{
+		{expr: "now", exp: now},
+		{expr: "now-7d", exp: now.AddDate(0, 0, -7)},
+		{expr: "now-1h30m", expErr: ErrInvalidParam},
+		{expr: "now-1h-30m", exp: now.Add(-90 * time.Minute)},
+		{expr: "today", exp: time.Date(2024, 3, 14, 0, 0, 0, 0, utc7)},
+		{expr: "yesterday", exp: time.Date(2024, 3, 13, 0, 0, 0, 0, utc7)},
+		{expr: "tomorrow", exp: time.Date(2024, 3, 15, 0, 0, 0, 0, utc7)},
+		{expr: "start_of_week", exp: time.Date(2024, 3, 11, 0, 0, 0, 0, utc7)},
+		{expr: "start_of_month", exp: time.Date(2024, 3, 1, 0, 0, 0, 0, utc7)},
+		{expr: "start_of_month+1M-1d", exp: time.Date(2024, 3, 31, 0, 0, 0, 0, utc7)},
+		{expr: "start_of_year-1y", exp: time.Date(2023, 1, 1, 0, 0, 0, 0, utc7)},
+		{expr: "2024-01-31", exp: time.Date(2024, 1, 31, 0, 0, 0, 0, utc7)},
+		{expr: "2024-01-31T10:00:00Z", exp: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)},
+		{expr: "", exp: time.Time{}},
+		{expr: "last_week", expErr: ErrInvalidParam},
+		{expr: "now-7", expErr: ErrInvalidParam},
+		{expr: "now-7x", expErr: ErrInvalidParam},
+		{expr: "now+", expErr: ErrInvalidParam},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.expr), func(t *testing.T) {
+			r, err := ParseRelativeTime(tc.expr)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expr, r.String())
+			assert.True(t, tc.exp.Equal(r.Resolve(now)), "got %v", r.Resolve(now))
+		})
+	}
+}
+
+func Test_RelativeTime_UnmarshalText(t *testing.T) {
+	var param ParamRelativeTime
+	err := json.Unmarshal([]byte(`{"CreatedAtGTE": "now-7d", "CreatedAtLTE": "today"}`), &param)
+	assert.Nil(t, err)
+	assert.Equal(t, "now-7d", param.CreatedAtGTE.String())
+	assert.Equal(t, "today", param.CreatedAtLTE.String())
+
+	err = json.Unmarshal([]byte(`{"CreatedAtGTE": "someday"}`), &param)
+	assert.ErrorIs(t, err, ErrInvalidParam)
+}
+
+func Test_QBuilder_RelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 14, 10, 30, 0, 0, time.UTC)
+	today := MustParseRelativeTime("today")
+	clock := WithClock(func() time.Time { return now })
+
+	testCase := []struct {
+		desc      string
+		param     ParamRelativeTime
+		expClause string
+		expArgs   []interface{}
+	}{
+		{
+			desc:      "zero",
+			param:     ParamRelativeTime{},
+			expClause: " WHERE 1=1 LIMIT 0, 10",
+		},
+		{
+			desc:      "resolved against the clock",
+			param:     ParamRelativeTime{CreatedAtGTE: MustParseRelativeTime("now-7d"), CreatedAtLTE: &today},
+			expClause: " WHERE 1=1 AND created_at >= ? AND created_at < ? LIMIT 0, 10",
+			expArgs:   []interface{}{time.Date(2024, 3, 7, 10, 30, 0, 0, time.UTC), time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(clock).Build(&tc.param)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package qbuilder
+
+import "reflect"
+
+// WithSoftDelete exclude the soft deleted rows, e.g: WithSoftDelete("deleted_at") => AND deleted_at IS NULL
+//
+// The predicate is added to Build, BuildCount, BuildCountQuery, BuildUpdate and BuildDelete,
+// it doesn't count as a filter for the empty where check of BuildUpdate and BuildDelete.
+// The param struct can opt out with a bool or *bool field:
+//
+//	WithDeleted bool `param:"with_deleted"` // include the soft deleted rows
+//	OnlyDeleted bool `param:"only_deleted"` // only the soft deleted rows, e.g: trash
+func WithSoftDelete(col string) Option {
+	return func(qb *queryBuilder) {
+		qb.softDeleteCol = col
+	}
+}
+
+// IsSoftDeleteScope report whether the field is the with_deleted or only_deleted param.
+func (c *cursor) IsSoftDeleteScope() bool {
+	return c.param == "with_deleted" || c.param == "only_deleted"
+}
+
+func (q *queryBuilder) handleParamSoftDelete(c cursor) {
+	field := c.field
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return
+		}
+		field = field.Elem()
+	}
+
+	if field.Kind() != reflect.Bool || !field.Bool() {
+		return
+	}
+
+	if c.param == "only_deleted" {
+		q.onlyDeleted = true
+	} else {
+		q.withDeleted = true
+	}
+}
+
+func (q *queryBuilder) appendSoftDelete() {
+	switch {
+	case q.softDeleteCol == "":
+	case q.onlyDeleted:
+		q.whereClause += " AND " + q.softDeleteCol + " IS NOT NULL"
+	case q.withDeleted:
+	default:
+		q.whereClause += " AND " + q.softDeleteCol + " IS NULL"
+	}
+}
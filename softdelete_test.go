@@ -0,0 +1,107 @@
+package qbuilder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamSoftDelete struct {
+	MerchantID  int64 `param:"merchant_id,omitempty" db:"merchant_id"`
+	WithDeleted bool  `param:"with_deleted"`
+	OnlyDeleted *bool `param:"only_deleted"`
+}
+
+type ParamInvalidSoftDelete struct {
+	WithDeleted string `param:"with_deleted"`
+}
+
+type SetSoftDelete struct {
+	Status string `db:"status"`
+}
+
+func Test_QBuilder_SoftDelete(t *testing.T) {
+	yes, no := true, false
+
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		param     ParamSoftDelete
+		expClause string
+	}{
+		{
+			desc:      "without soft delete",
+			param:     ParamSoftDelete{},
+			expClause: " WHERE 1=1 LIMIT 0, 10",
+		},
+		{
+			desc:      "exclude deleted",
+			opt:       []Option{WithSoftDelete("deleted_at")},
+			param:     ParamSoftDelete{MerchantID: 10},
+			expClause: " WHERE 1=1 AND merchant_id = ? AND deleted_at IS NULL LIMIT 0, 10",
+		},
+		{
+			desc:      "with deleted",
+			opt:       []Option{WithSoftDelete("deleted_at")},
+			param:     ParamSoftDelete{MerchantID: 10, WithDeleted: true, OnlyDeleted: &no},
+			expClause: " WHERE 1=1 AND merchant_id = ? LIMIT 0, 10",
+		},
+		{
+			desc:      "only deleted",
+			opt:       []Option{WithSoftDelete("p.deleted_at")},
+			param:     ParamSoftDelete{MerchantID: 10, WithDeleted: true, OnlyDeleted: &yes},
+			expClause: " WHERE 1=1 AND merchant_id = ? AND p.deleted_at IS NOT NULL LIMIT 0, 10",
+		},
+		{
+			desc:      "after custom where clause",
+			opt:       []Option{WithSoftDelete("deleted_at"), WithWhereClause("status = ?", "active")},
+			param:     ParamSoftDelete{},
+			expClause: " WHERE 1=1 AND status = ? AND deleted_at IS NULL LIMIT 0, 10",
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, _, err := New(tc.opt...).Build(&tc.param)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+		})
+	}
+
+	t.Run("count", func(t *testing.T) {
+		q := New(WithSoftDelete("deleted_at"))
+		_, _, err := q.Build(&ParamSoftDelete{MerchantID: 10})
+		assert.Nil(t, err)
+
+		query, args, err := q.BuildCountQuery("SELECT id FROM product")
+		assert.Nil(t, err)
+		assert.Equal(t, "SELECT COUNT(*) FROM (SELECT id FROM product WHERE 1=1 AND merchant_id = ? AND deleted_at IS NULL) AS qbuilder_count", query)
+		assert.Equal(t, []interface{}{int64(10)}, args)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		clause, args, err := New(WithSoftDelete("deleted_at")).BuildUpdate(&SetSoftDelete{Status: "inactive"}, &ParamSoftDelete{MerchantID: 10})
+		assert.Nil(t, err)
+		assert.Equal(t, " SET status = ? WHERE 1=1 AND merchant_id = ? AND deleted_at IS NULL", clause)
+		assert.Equal(t, []interface{}{"inactive", int64(10)}, args)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		clause, _, err := New(WithSoftDelete("deleted_at")).BuildDelete(&ParamSoftDelete{MerchantID: 10, OnlyDeleted: &yes})
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND merchant_id = ? AND deleted_at IS NOT NULL", clause)
+
+		// the soft delete predicate is not a filter
+		_, _, err = New(WithSoftDelete("deleted_at")).BuildDelete(&ParamSoftDelete{})
+		assert.ErrorIs(t, err, ErrEmptyWhere)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := For[ParamInvalidSoftDelete]()
+		assert.ErrorIs(t, err, ErrUnsupportedType)
+
+		_, err = For[ParamSoftDelete]()
+		assert.Nil(t, err)
+	})
+}
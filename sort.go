@@ -0,0 +1,130 @@
+package qbuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NullsOrder is the position of NULL values in ORDER BY.
+type NullsOrder int
+
+const (
+	NullsDefault NullsOrder = iota
+	NullsFirst
+	NullsLast
+)
+
+// SortKey declare how the sort key sent by the client is rendered in ORDER BY.
+//
+// e.g:
+//
+//	WithSortKey("priority", SortKey{Expr: "status", Order: []string{"URGENT", "HIGH", "LOW"}})
+//	WithSortKey("city", SortKey{Expr: "address", JSONPath: "$.city", Nulls: NullsLast})
+type SortKey struct {
+	Expr     string     // column or sql expression, default is the key itself
+	JSONPath string     // sort by the value of the JSON path in Expr, e.g: $.a.b
+	Order    []string   // custom order of the values, rendered as CASE
+	Nulls    NullsOrder // NULLS FIRST / LAST, emulated with `expr IS NULL` in MySQL
+
+	// arguments of the placeholders in Expr
+	Args []interface{}
+
+	// derived from the param struct (e.g: relevance of the fulltext search) instead of WithSortKey,
+	// it's omitted from ORDER BY when the field is not set.
+	derived bool
+	omit    bool
+}
+
+// WithSortKey declare the sort key (alias) and how it's rendered.
+//
+// Once a sort key is declared, only the declared keys and the sortable fields are allowed in sort_by.
+func WithSortKey(key string, sk SortKey) Option {
+	return func(qb *queryBuilder) {
+		qb.addSortKey(key, sk)
+	}
+}
+
+func (q *queryBuilder) addSortKey(key string, sk SortKey) {
+	if q.sortKeys == nil {
+		q.sortKeys = make(map[string]SortKey)
+	}
+	q.sortKeys[key] = sk
+}
+
+// makeOrderByItem render the sort key, e.g: -created_at => created_at DESC
+func (q *queryBuilder) makeOrderByItem(v string) (item string, args []interface{}, ok bool) {
+	key, direction := v, " ASC"
+	if strings.HasPrefix(v, "-") {
+		key, direction = v[1:], " DESC"
+	}
+
+	sk, declared := q.sortKeys[key]
+	if !declared {
+		return key + direction, nil, true
+	}
+
+	if sk.omit {
+		return "", nil, false
+	}
+
+	expr := key
+	if sk.Expr != "" {
+		expr = sk.Expr
+	}
+
+	if sk.JSONPath != "" {
+		expr = q.makeJSONPathExpr(expr, sk.JSONPath)
+	}
+
+	if len(sk.Order) > 0 {
+		expr = makeCaseExpr(expr, sk.Order)
+	}
+
+	switch {
+	case sk.Nulls == NullsDefault:
+		return expr + direction, sk.Args, true
+	case q.dialect == Postgres && sk.Nulls == NullsFirst:
+		return expr + direction + " NULLS FIRST", sk.Args, true
+	case q.dialect == Postgres && sk.Nulls == NullsLast:
+		return expr + direction + " NULLS LAST", sk.Args, true
+	case sk.Nulls == NullsFirst:
+		return expr + " IS NULL DESC, " + expr + direction, append(append([]interface{}{}, sk.Args...), sk.Args...), true
+	default:
+		return expr + " IS NULL ASC, " + expr + direction, append(append([]interface{}{}, sk.Args...), sk.Args...), true
+	}
+}
+
+// makeJSONPathExpr return the unquoted value of the JSON path.
+//
+// e.g: $.a.b => MySQL: JSON_UNQUOTE(JSON_EXTRACT(col, '$.a.b')), Postgres: col #>> '{a,b}'
+func (q *queryBuilder) makeJSONPathExpr(expr, path string) string {
+	if q.dialect != Postgres {
+		return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, '%s'))", expr, escapeQuote(path))
+	}
+
+	var keys []string
+	for _, k := range strings.FieldsFunc(strings.TrimPrefix(path, "$"), func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	}) {
+		keys = append(keys, strings.Trim(k, `"`))
+	}
+
+	return fmt.Sprintf("%s #>> '{%s}'", expr, escapeQuote(strings.Join(keys, ",")))
+}
+
+// makeCaseExpr return the CASE expression of the custom order, other values are sorted last.
+//
+// e.g: CASE status WHEN 'URGENT' THEN 0 WHEN 'HIGH' THEN 1 ELSE 2 END
+func makeCaseExpr(expr string, order []string) string {
+	caseExpr := "CASE " + expr
+	for i, v := range order {
+		caseExpr += fmt.Sprintf(" WHEN '%s' THEN %d", escapeQuote(v), i)
+	}
+	caseExpr += fmt.Sprintf(" ELSE %d END", len(order))
+
+	return caseExpr
+}
+
+func escapeQuote(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
@@ -0,0 +1,80 @@
+package qbuilder
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_QBuilder_SortKey(t *testing.T) {
+	sortKeys := []Option{
+		WithSortKey("name", SortKey{}),
+		WithSortKey("published_at", SortKey{Nulls: NullsLast}),
+		WithSortKey("updated_at", SortKey{Nulls: NullsFirst}),
+		WithSortKey("city", SortKey{Expr: "address", JSONPath: "$.city"}),
+		WithSortKey("tag", SortKey{Expr: "tags", JSONPath: "$[0].name"}),
+		WithSortKey("priority", SortKey{Expr: "status", Order: []string{"URGENT", "HIGH", "IT'S LOW"}}),
+	}
+
+	testCase := []struct {
+		desc      string
+		dialect   Dialect
+		sortBy    []string
+		expClause string
+	}{
+		{
+			desc:      "alias without expression",
+			sortBy:    []string{"-name"},
+			expClause: " WHERE 1=1 ORDER BY name DESC LIMIT 0, 10",
+		},
+		{
+			desc:      "mysql nulls last",
+			sortBy:    []string{"published_at"},
+			expClause: " WHERE 1=1 ORDER BY published_at IS NULL ASC, published_at ASC LIMIT 0, 10",
+		},
+		{
+			desc:      "mysql nulls first",
+			sortBy:    []string{"-updated_at"},
+			expClause: " WHERE 1=1 ORDER BY updated_at IS NULL DESC, updated_at DESC LIMIT 0, 10",
+		},
+		{
+			desc:      "postgres nulls",
+			dialect:   Postgres,
+			sortBy:    []string{"-published_at", "updated_at"},
+			expClause: " WHERE 1=1 ORDER BY published_at DESC NULLS LAST, updated_at ASC NULLS FIRST LIMIT 10 OFFSET 0",
+		},
+		{
+			desc:      "mysql json path",
+			sortBy:    []string{"city", "-tag"},
+			expClause: " WHERE 1=1 ORDER BY JSON_UNQUOTE(JSON_EXTRACT(address, '$.city')) ASC, JSON_UNQUOTE(JSON_EXTRACT(tags, '$[0].name')) DESC LIMIT 0, 10",
+		},
+		{
+			desc:      "postgres json path",
+			dialect:   Postgres,
+			sortBy:    []string{"city", "-tag"},
+			expClause: " WHERE 1=1 ORDER BY address #>> '{city}' ASC, tags #>> '{0,name}' DESC LIMIT 10 OFFSET 0",
+		},
+		{
+			desc:      "custom order",
+			sortBy:    []string{"priority"},
+			expClause: " WHERE 1=1 ORDER BY CASE status WHEN 'URGENT' THEN 0 WHEN 'HIGH' THEN 1 WHEN 'IT''S LOW' THEN 2 ELSE 3 END ASC LIMIT 0, 10",
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			param := ParamPaginationInt64{ShortBy: tc.sortBy}
+			clause, _, err := New(append(sortKeys, WithDialect(tc.dialect))...).Build(&param)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+		})
+	}
+
+	t.Run("undeclared sort key", func(t *testing.T) {
+		param := ParamPaginationInt64{ShortBy: []string{"password"}}
+		_, _, err := New(sortKeys...).Build(&param)
+		assert.True(t, errors.Is(err, ErrSortFieldNotAllowed))
+	})
+}
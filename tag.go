@@ -0,0 +1,24 @@
+package qbuilder
+
+import "strings"
+
+// tagOptions is the comma separated options of a struct tag, e.g: db:"id,auto_increment"
+type tagOptions []string
+
+// parseTag split the struct tag into name and options.
+func parseTag(tag string) (string, tagOptions) {
+	name, opts, found := strings.Cut(tag, ",")
+	if !found {
+		return name, nil
+	}
+	return name, strings.Split(opts, ",")
+}
+
+func (o tagOptions) Has(opt string) bool {
+	for _, v := range o {
+		if v == opt {
+			return true
+		}
+	}
+	return false
+}
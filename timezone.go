@@ -0,0 +1,112 @@
+package qbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// truncFunc return the [start, next) range of the truncated time.
+type truncFunc func(t time.Time) (start, next time.Time)
+
+// supported tag:"trunc", the time is truncated in its own location
+var truncFuncs = map[string]truncFunc{
+	"hour": func(t time.Time) (time.Time, time.Time) {
+		start := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+		return start, start.Add(time.Hour)
+	},
+	"day": func(t time.Time) (time.Time, time.Time) {
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return start, start.AddDate(0, 0, 1)
+	},
+	"month": func(t time.Time) (time.Time, time.Time) {
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return start, start.AddDate(0, 1, 0)
+	},
+}
+
+// WithLocation convert the time values to loc before binding, e.g: the location of the database session.
+//
+// Truncation (tag:"trunc") is done in the location of the value, before the conversion,
+// so 2024-01-31 in UTC+7 is the day from 2024-01-30 17:00 to 2024-01-31 17:00 UTC.
+// The clock is read in loc, so RelativeTime anchors and layouts without zone are resolved in loc.
+func WithLocation(loc *time.Location) Option {
+	return func(qb *queryBuilder) {
+		qb.location = loc
+	}
+}
+
+func (q *queryBuilder) normalizeTime(t time.Time) time.Time {
+	if q.location != nil {
+		return t.In(q.location)
+	}
+	return t
+}
+
+// IsTime report whether the field is time.Time, sql.NullTime or RelativeTime, or a pointer to them.
+func (c *cursor) IsTime() bool {
+	t := c.field.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch reflect.Zero(t).Interface().(type) {
+	case time.Time, sql.NullTime, RelativeTime:
+		return true
+	default:
+		return false
+	}
+}
+
+// TruncFunc return the truncation from tag:"trunc", nil if the time is not truncated.
+//
+// e.g: `param:"created_at__lte" db:"created_at" trunc:"day"` => created_at < ? (the next day)
+func (c *cursor) TruncFunc() (truncFunc, error) {
+	tag := c.tag.Get("trunc")
+	if tag == "" {
+		return nil, nil
+	}
+
+	if !c.IsTime() {
+		return nil, fmt.Errorf("trunc is only supported for time, got %s", c.field.Type())
+	}
+
+	fn, ok := truncFuncs[tag]
+	if !ok {
+		return nil, fmt.Errorf("unknown trunc %q, should be hour, day or month", tag)
+	}
+
+	return fn, nil
+}
+
+// makeClauseTimeRange render the operator on the truncated [start, next) range.
+//
+//	=       col >= start AND col < next
+//	__neq   (col < start OR col >= next)
+//	__gt    col >= next
+//	__gte   col >= start
+//	__lt    col < start
+//	__lte   col < next
+func (c *cursor) makeClauseTimeRange(operand string, start, next time.Time) (clause string, args []interface{}, skip bool) {
+	col := c.Column()
+	start, next = c.qb.normalizeTime(start), c.qb.normalizeTime(next)
+
+	switch operand {
+	case ">":
+		return c.makeClause(whereClauseFmt, ">=", next)
+	case ">=":
+		return c.makeClause(whereClauseFmt, ">=", start)
+	case "<":
+		return c.makeClause(whereClauseFmt, "<", start)
+	case "<=":
+		return c.makeClause(whereClauseFmt, "<", next)
+	case "!=":
+		clause = fmt.Sprintf(" AND (%s < ? OR %s >= ?)", col, col)
+	default:
+		clause = fmt.Sprintf(" AND %s >= ? AND %s < ?", col, col)
+	}
+	args = append(args, start, next)
+
+	return
+}
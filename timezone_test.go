@@ -0,0 +1,138 @@
+package qbuilder
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamTrunc struct {
+	Day      time.Time  `param:"day" db:"created_at" trunc:"day"`
+	DayNEQ   time.Time  `param:"day__neq" db:"created_at" trunc:"day"`
+	DayGT    time.Time  `param:"day__gt" db:"created_at" trunc:"day"`
+	DayGTE   time.Time  `param:"day__gte" db:"created_at" trunc:"day"`
+	DayLT    time.Time  `param:"day__lt" db:"created_at" trunc:"day"`
+	DayLTE   time.Time  `param:"day__lte" db:"created_at" trunc:"day"`
+	Hour     *time.Time `param:"hour" db:"created_at" trunc:"hour"`
+	MonthLTE time.Time  `param:"month__lte" db:"created_at" trunc:"month"`
+}
+
+type ParamInvalidTrunc struct {
+	Day time.Time `param:"day" db:"created_at" trunc:"week"`
+}
+
+type ParamTruncNotTime struct {
+	Day string `param:"day" db:"created_at" trunc:"day"`
+}
+
+func Test_QBuilder_TimeZone(t *testing.T) {
+	utc7 := time.FixedZone("UTC+7", 7*60*60)
+	ts := time.Date(2024, 1, 31, 10, 30, 0, 0, utc7)
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, utc7) }
+
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		param     interface{}
+		expClause string
+		expArgs   []interface{}
+		expErr    error
+	}{
+		{
+			desc:      "location",
+			opt:       []Option{WithLocation(time.UTC)},
+			param:     &ParamTime{Time: ts},
+			expClause: " WHERE 1=1 AND time = ? LIMIT 0, 10",
+			expArgs:   []interface{}{time.Date(2024, 1, 31, 3, 30, 0, 0, time.UTC)},
+		},
+		{
+			desc:  "truncate day",
+			param: &ParamTrunc{Day: ts, DayNEQ: ts, DayGT: ts, DayGTE: ts, DayLT: ts, DayLTE: ts},
+			expClause: " WHERE 1=1 AND created_at >= ? AND created_at < ? AND (created_at < ? OR created_at >= ?)" +
+				" AND created_at >= ? AND created_at >= ? AND created_at < ? AND created_at < ? LIMIT 0, 10",
+			expArgs: []interface{}{day(31), day(31).AddDate(0, 0, 1), day(31), day(31).AddDate(0, 0, 1),
+				day(31).AddDate(0, 0, 1), day(31), day(31), day(31).AddDate(0, 0, 1)},
+		},
+		{
+			desc:      "truncate hour and month",
+			param:     &ParamTrunc{Hour: &ts, MonthLTE: ts},
+			expClause: " WHERE 1=1 AND created_at >= ? AND created_at < ? AND created_at < ? LIMIT 0, 10",
+			expArgs: []interface{}{time.Date(2024, 1, 31, 10, 0, 0, 0, utc7), time.Date(2024, 1, 31, 11, 0, 0, 0, utc7),
+				time.Date(2024, 2, 1, 0, 0, 0, 0, utc7)},
+		},
+		{
+			desc:      "truncate in the value location before normalization",
+			opt:       []Option{WithLocation(time.UTC)},
+			param:     &ParamTrunc{DayLTE: ts},
+			expClause: " WHERE 1=1 AND created_at < ? LIMIT 0, 10",
+			expArgs:   []interface{}{time.Date(2024, 1, 31, 17, 0, 0, 0, time.UTC)},
+		},
+		{
+			desc:   "invalid trunc",
+			param:  &ParamInvalidTrunc{},
+			expErr: ErrInvalidParam,
+		},
+		{
+			desc:   "trunc on non time field",
+			param:  &ParamTruncNotTime{},
+			expErr: ErrInvalidParam,
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(tc.opt...).Build(tc.param)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+
+	t.Run("location instant and day boundaries", func(t *testing.T) {
+		date := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+		_, args, err := New(WithLocation(utc7)).Build(&ParamTime{Time: date})
+		assert.Nil(t, err)
+		assert.True(t, args[0].(time.Time).Equal(date), args[0])
+		assert.Equal(t, utc7, args[0].(time.Time).Location())
+
+		// 2024-01-31T23:00Z is on Jan 31 in its own location
+		_, args, err = New(WithLocation(utc7)).Build(&ParamTrunc{Day: date.Add(23 * time.Hour)})
+		assert.Nil(t, err)
+		assert.True(t, args[0].(time.Time).Equal(date), args[0])
+		assert.True(t, args[1].(time.Time).Equal(date.AddDate(0, 0, 1)), args[1])
+	})
+
+	t.Run("relative time in the location", func(t *testing.T) {
+		now := time.Date(2024, 1, 31, 20, 0, 0, 0, time.UTC) // 2024-02-01 03:00 in UTC+7
+		opt := []Option{WithLocation(utc7), WithClock(func() time.Time { return now })}
+
+		_, args, err := New(opt...).Build(&ParamRelativeTime{CreatedAtGTE: MustParseRelativeTime("today")})
+		assert.Nil(t, err)
+		assert.True(t, args[0].(time.Time).Equal(time.Date(2024, 1, 31, 17, 0, 0, 0, time.UTC)), args[0])
+
+		// the layout without zone is in the location, the layout with zone keep its instant
+		_, args, err = New(opt...).Build(&ParamRelativeTime{CreatedAtGTE: MustParseRelativeTime("2024-01-31 03:00:00")})
+		assert.Nil(t, err)
+		assert.True(t, args[0].(time.Time).Equal(time.Date(2024, 1, 30, 20, 0, 0, 0, time.UTC)), args[0])
+
+		_, args, err = New(opt...).Build(&ParamRelativeTime{CreatedAtGTE: MustParseRelativeTime("2024-01-31T03:00:00Z")})
+		assert.Nil(t, err)
+		assert.True(t, args[0].(time.Time).Equal(time.Date(2024, 1, 31, 3, 0, 0, 0, time.UTC)), args[0])
+	})
+
+	t.Run("validated on construction", func(t *testing.T) {
+		_, err := For[ParamTrunc]()
+		assert.Nil(t, err)
+
+		_, err = For[ParamInvalidTrunc]()
+		assert.ErrorIs(t, err, ErrInvalidParam)
+	})
+}
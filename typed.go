@@ -0,0 +1,137 @@
+package qbuilder
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Builder is the type safe query builder of the param struct P, create it with For.
+//
+// Unlike New, a Builder can be reused and shared between goroutines,
+// every Build use a new query builder with the same options.
+type Builder[P any] struct {
+	opts []Option
+}
+
+// For create the query builder of the param struct P,
+// the struct tags are validated once here instead of on every Build.
+//
+// e.g: qb, err := qbuilder.For[ProductParam](qbuilder.WithExtraLimit())
+func For[P any](opts ...Option) (*Builder[P], error) {
+	var p P
+	if err := compileParamType(reflect.TypeOf(p)); err != nil {
+		return nil, err
+	}
+
+	return &Builder[P]{opts: opts}, nil
+}
+
+// MustFor is like For but panics if the param struct is invalid.
+func MustFor[P any](opts ...Option) *Builder[P] {
+	b, err := For[P](opts...)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// New return the underlying query builder, e.g: to add custom where clause.
+func (b *Builder[P]) New() *queryBuilder {
+	return New(b.opts...)
+}
+
+func (b *Builder[P]) Build(param P) (sqlClause string, args []interface{}, err error) {
+	return b.New().Build(&param)
+}
+
+func (b *Builder[P]) BuildCount(param P) (sqlClause string, args []interface{}, err error) {
+	q := b.New()
+	if err = q.build(&param); err != nil {
+		return
+	}
+	return q.BuildCount()
+}
+
+func (b *Builder[P]) BuildCountQuery(selectQuery string, param P) (query string, args []interface{}, err error) {
+	q := b.New()
+	if err = q.build(&param); err != nil {
+		return
+	}
+	return q.BuildCountQuery(selectQuery)
+}
+
+// validateParamType validate the param struct type and its tags.
+func validateParamType(t reflect.Type) error {
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: should be a struct, got %v", ErrInvalidParam, t)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		c := newCursor(reflect.Zero(sf.Type), sf.Tag, New())
+
+		switch {
+		case c.IsPage(), c.IsLimit():
+			if k := sf.Type.Kind(); k != reflect.Int && k != reflect.Int64 {
+				return newFieldError(ErrUnsupportedType, sf.Name, c.param, "should be int or int64, got %s", sf.Type)
+			}
+			for _, tag := range []string{"default", "max"} {
+				if _, err := parseLimitTag(sf.Tag.Get(tag), 0); err != nil {
+					return newFieldError(ErrInvalidParam, sf.Name, c.param, "tag %s %v", tag, err)
+				}
+			}
+		case c.IsSortBy():
+			if sf.Type != reflect.TypeOf([]string(nil)) && sf.Type != reflect.TypeOf("") {
+				return newFieldError(ErrUnsupportedType, sf.Name, c.param, "should be []string or string, got %s", sf.Type)
+			}
+		case c.IsSoftDeleteScope():
+			if sf.Type != reflect.TypeOf(false) && sf.Type != reflect.TypeOf((*bool)(nil)) {
+				return newFieldError(ErrUnsupportedType, sf.Name, c.param, "should be bool or *bool, got %s", sf.Type)
+			}
+		case c.IsEmpty():
+		default:
+			if err := validateFilterField(c, sf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateFilterField validate the type and tags of the filter field.
+func validateFilterField(c cursor, sf reflect.StructField) error {
+	if !sf.IsExported() {
+		return newFieldError(ErrInvalidParam, sf.Name, c.param, "unexported field cannot be used as param")
+	}
+
+	if !c.IsSupported() {
+		return newFieldError(ErrUnsupportedType, sf.Name, c.param, "%s", sf.Type)
+	}
+
+	if reason := c.CheckOperator(); reason != "" {
+		return newFieldError(ErrInvalidOperator, sf.Name, c.param, "%s", reason)
+	}
+
+	if _, err := c.EmptySlicePolicy(); err != nil {
+		return newFieldError(ErrInvalidParam, sf.Name, c.param, "%v", err)
+	}
+
+	if _, err := c.DurationUnit(); err != nil {
+		return newFieldError(ErrInvalidParam, sf.Name, c.param, "%v", err)
+	}
+
+	if _, err := c.TruncFunc(); err != nil {
+		return newFieldError(ErrInvalidParam, sf.Name, c.param, "%v", err)
+	}
+
+	if c.IsRequired() && c.opts.Has("omitempty") {
+		return newFieldError(ErrInvalidParam, sf.Name, c.param, "omitempty and required cannot be used together")
+	}
+
+	if c.IsAggregate() && !c.IsValidAggregate() {
+		return newFieldError(ErrInvalidParam, sf.Name, c.param, "unsupported aggregate %q", c.agg)
+	}
+
+	return nil
+}
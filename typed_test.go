@@ -0,0 +1,74 @@
+package qbuilder
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParamUnsupported struct {
+	Map map[string]string `param:"map" db:"map"`
+}
+
+type ParamInvalidPage struct {
+	Page string `param:"page"`
+}
+
+func Test_For(t *testing.T) {
+	t.Run("valid param", func(t *testing.T) {
+		for _, err := range []error{
+			func() error { _, err := For[ParamPrimitive](); return err }(),
+			func() error { _, err := For[ParamArr](); return err }(),
+			func() error { _, err := For[ParamNull](); return err }(),
+			func() error { _, err := For[ParamPaginationInt](); return err }(),
+			func() error { _, err := For[ParamJsonSearch](); return err }(),
+			func() error { _, err := For[ParamSkip](); return err }(),
+			func() error { _, err := For[ParamSortString](); return err }(),
+		} {
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("invalid param", func(t *testing.T) {
+		for _, err := range []error{
+			func() error { _, err := For[*ParamPrimitive](); return err }(),
+			func() error { _, err := For[map[string]interface{}](); return err }(),
+			func() error { _, err := For[interface{}](); return err }(),
+			func() error { _, err := For[ParamUnsupported](); return err }(),
+			func() error { _, err := For[ParamInvalidPage](); return err }(),
+			func() error { _, err := For[ParamInvalidAggregate](); return err }(),
+			func() error { _, err := For[ParamInvalidLimitTag](); return err }(),
+		} {
+			assert.NotNil(t, err)
+		}
+	})
+
+	t.Run("must for", func(t *testing.T) {
+		assert.NotPanics(t, func() { MustFor[ParamNull]() })
+		assert.Panics(t, func() { MustFor[int]() })
+	})
+}
+
+func Test_Builder_Build(t *testing.T) {
+	qb := MustFor[ParamNull](WithExtraLimit())
+	param := ParamNull{NullInt64: sql.NullInt64{Valid: true, Int64: 30}}
+
+	// reusable, every build start from a new query builder
+	for i := 0; i < 2; i++ {
+		clause, args, err := qb.Build(param)
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND nullint64 = ? LIMIT 0, 11", clause)
+		assert.Equal(t, []interface{}{int64(30)}, args)
+	}
+
+	clause, args, err := qb.BuildCount(param)
+	assert.Nil(t, err)
+	assert.Equal(t, " WHERE 1=1 AND nullint64 = ?", clause)
+	assert.Equal(t, []interface{}{int64(30)}, args)
+
+	query, args, err := qb.BuildCountQuery("SELECT id FROM product", param)
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM (SELECT id FROM product WHERE 1=1 AND nullint64 = ?) AS qbuilder_count", query)
+	assert.Equal(t, []interface{}{int64(30)}, args)
+}
@@ -0,0 +1,156 @@
+package qbuilder
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// WithAllowEmptyWhere allow BuildUpdate and BuildDelete to run without any where clause.
+//
+// By default they return an error, to prevent updating or deleting the whole table by accident.
+func WithAllowEmptyWhere() Option {
+	return func(qb *queryBuilder) {
+		qb.allowEmptyWhere = true
+	}
+}
+
+// Add custom set clause for BuildUpdate
+//
+// e.g: AddSetClause("updated_at = NOW()") or AddSetClause("deleted_at = ?", nil)
+func (q *queryBuilder) AddSetClause(sc string, args ...interface{}) *queryBuilder {
+	q.customSetClause = append(q.customSetClause, sc)
+	q.customSetClauseArgs = append(q.customSetClauseArgs, args...)
+
+	return q
+}
+
+// BuildUpdate return SET and WHERE clause for UPDATE query.
+// set is a pointer to struct with db tags, unset fields (invalid sql.Null*, nil pointer, zero time.Time) are skipped.
+//
+// e.g: " SET status = ? WHERE 1=1 AND merchant_id = ?"
+func (q *queryBuilder) BuildUpdate(set interface{}, param interface{}) (sqlClause string, args []interface{}, err error) {
+	setClause, setArgs, err := q.makeSetClause(set)
+	if err != nil {
+		return
+	}
+
+	if err = q.build(param); err != nil {
+		return
+	}
+
+	if err = q.checkUngrouped(); err != nil {
+		return
+	}
+
+	if err = q.checkEmptyWhere(); err != nil {
+		return
+	}
+
+	sqlClause = setClause + q.whereClause
+	args = append(setArgs, q.args...)
+
+	return sqlClause, args, nil
+}
+
+// BuildDelete return WHERE clause for DELETE query.
+//
+// e.g: " WHERE 1=1 AND merchant_id = ?"
+func (q *queryBuilder) BuildDelete(param interface{}) (sqlClause string, args []interface{}, err error) {
+	if err = q.build(param); err != nil {
+		return
+	}
+
+	if err = q.checkUngrouped(); err != nil {
+		return
+	}
+
+	if err = q.checkEmptyWhere(); err != nil {
+		return
+	}
+
+	sqlClause = q.whereClause
+	args = q.args
+
+	return sqlClause, args, nil
+}
+
+// checkUngrouped return an error if the query has GROUP BY or HAVING clause (e.g: agg tag),
+// UPDATE and DELETE only use the where clause, so the having filters would be dropped.
+func (q *queryBuilder) checkUngrouped() error {
+	if q.isGrouped() {
+		return fmt.Errorf("%w: GROUP BY, HAVING and aggregate filters are not supported by UPDATE and DELETE", ErrInvalidParam)
+	}
+	return nil
+}
+
+func (q *queryBuilder) checkEmptyWhere() error {
+	if q.filterCount == 0 && len(q.customWhereClause) == 0 && !q.allowEmptyWhere {
+		return fmt.Errorf("%w, use WithAllowEmptyWhere to allow it", ErrEmptyWhere)
+	}
+	return nil
+}
+
+func (q *queryBuilder) makeSetClause(set interface{}) (setClause string, args []interface{}, err error) {
+	s := reflect.ValueOf(set)
+	if s.Kind() != reflect.Ptr || s.IsNil() || s.Elem().Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("%w: set should be a pointer to struct and cannot be nil", ErrInvalidParam)
+	}
+
+	val := s.Elem()
+	var cols []string
+
+	for i := 0; i < val.NumField(); i++ {
+		tagDB, _ := parseTag(val.Type().Field(i).Tag.Get("db"))
+		if tagDB == "" || tagDB == "-" {
+			continue
+		}
+
+		v, ok := setValue(val.Field(i))
+		if !ok {
+			continue
+		}
+
+		cols = append(cols, tagDB+" = ?")
+		args = append(args, v)
+	}
+
+	cols = append(cols, q.customSetClause...)
+	args = append(args, q.customSetClauseArgs...)
+
+	if len(cols) == 0 {
+		return "", nil, fmt.Errorf("%w: nothing to update", ErrInvalidParam)
+	}
+
+	return " SET " + strings.Join(cols, ", "), args, nil
+}
+
+// setValue return the value to be set, ok is false if the field is unset.
+func setValue(field reflect.Value) (val interface{}, ok bool) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, false
+		}
+		field = field.Elem()
+	}
+
+	if !field.CanInterface() {
+		return nil, false
+	}
+
+	switch v := field.Interface().(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case driver.Valuer:
+		// sql.Null* return nil when it's not valid
+		dv, err := v.Value()
+		if err != nil || dv == nil {
+			return nil, false
+		}
+		return dv, true
+	default:
+		return v, true
+	}
+}
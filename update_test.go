@@ -0,0 +1,180 @@
+package qbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type SetProduct struct {
+	Status    sql.NullString `db:"status"`
+	Stock     sql.NullInt64  `db:"stock"`
+	Price     *float64       `db:"price"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	Note      string         `db:"-"`
+}
+
+func Test_QBuilder_BuildUpdate(t *testing.T) {
+	price := 9.5
+	updatedAt := time.Date(2022, 06, 19, 10, 0, 0, 0, time.Local)
+
+	testCase := []struct {
+		desc      string
+		opt       []Option
+		set       SetProduct
+		param     ParamNull
+		expClause string
+		expArgs   []interface{}
+		expErr    bool
+	}{
+		{
+			desc: "skip unset fields",
+			set: SetProduct{
+				Status: sql.NullString{Valid: true, String: "ARCHIVED"},
+				Price:  &price,
+				Note:   "ignored",
+			},
+			param: ParamNull{
+				NullInt64: sql.NullInt64{Valid: true, Int64: 30},
+			},
+			expClause: " SET status = ?, price = ? WHERE 1=1 AND nullint64 = ?",
+			expArgs:   []interface{}{"ARCHIVED", 9.5, int64(30)},
+		},
+		{
+			desc: "all fields",
+			set: SetProduct{
+				Status:    sql.NullString{Valid: true, String: "ACTIVE"},
+				Stock:     sql.NullInt64{Valid: true, Int64: 0},
+				Price:     &price,
+				UpdatedAt: updatedAt,
+			},
+			param: ParamNull{
+				NullString: sql.NullString{Valid: true, String: "foo"},
+			},
+			expClause: " SET status = ?, stock = ?, price = ?, updated_at = ? WHERE 1=1 AND nullstring LIKE ?",
+			expArgs:   []interface{}{"ACTIVE", int64(0), 9.5, updatedAt, "foo"},
+		},
+		{
+			desc: "nothing to update",
+			set:  SetProduct{},
+			param: ParamNull{
+				NullInt64: sql.NullInt64{Valid: true, Int64: 30},
+			},
+			expErr: true,
+		},
+		{
+			desc: "empty where clause",
+			set: SetProduct{
+				Status: sql.NullString{Valid: true, String: "ARCHIVED"},
+			},
+			param:  ParamNull{},
+			expErr: true,
+		},
+		{
+			desc: "empty where clause is allowed",
+			opt:  []Option{WithAllowEmptyWhere()},
+			set: SetProduct{
+				Status: sql.NullString{Valid: true, String: "ARCHIVED"},
+			},
+			param:     ParamNull{},
+			expClause: " SET status = ? WHERE 1=1",
+			expArgs:   []interface{}{"ARCHIVED"},
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			clause, args, err := New(tc.opt...).BuildUpdate(&tc.set, &tc.param)
+			if tc.expErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expClause, clause)
+			assert.Equal(t, tc.expArgs, args)
+		})
+	}
+
+	t.Run("custom set clause", func(t *testing.T) {
+		qb := New()
+		qb.AddSetClause("updated_at = NOW()")
+		qb.AddWhereClause("id = ?", 1)
+		clause, args, err := qb.BuildUpdate(&SetProduct{}, &ParamNull{})
+		assert.Nil(t, err)
+		assert.Equal(t, " SET updated_at = NOW() WHERE 1=1 AND id = ?", clause)
+		assert.Equal(t, []interface{}{1}, args)
+	})
+}
+
+func Test_QBuilder_BuildDelete(t *testing.T) {
+	t.Run("with where clause", func(t *testing.T) {
+		param := ParamPaginationInt64{Page: 2, Limit: 10, ShortBy: []string{"-id"}}
+		qb := New()
+		qb.AddWhereClause("status = ?", "EXPIRED")
+		clause, args, err := qb.BuildDelete(&param)
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1 AND status = ?", clause)
+		assert.Equal(t, []interface{}{"EXPIRED"}, args)
+	})
+
+	t.Run("empty where clause", func(t *testing.T) {
+		_, _, err := New().BuildDelete(&ParamNull{})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("empty where clause is allowed", func(t *testing.T) {
+		clause, args, err := New(WithAllowEmptyWhere()).BuildDelete(&ParamNull{})
+		assert.Nil(t, err)
+		assert.Equal(t, " WHERE 1=1", clause)
+		assert.Nil(t, args)
+	})
+}
+
+func Test_QBuilder_BuildUpdateGrouped(t *testing.T) {
+	testCase := []struct {
+		desc  string
+		qb    *queryBuilder
+		param ParamAggregate
+	}{
+		{
+			desc: "aggregate filter",
+			qb:   New(),
+			param: ParamAggregate{
+				MerchantID: sql.NullInt64{Valid: true, Int64: 1},
+				TotalGTE:   sql.NullFloat64{Valid: true, Float64: 100},
+			},
+		},
+		{
+			desc:  "group by",
+			qb:    New().GroupBy("merchant_id"),
+			param: ParamAggregate{MerchantID: sql.NullInt64{Valid: true, Int64: 1}},
+		},
+		{
+			desc:  "custom having clause",
+			qb:    New().AddHavingClause("MAX(amount) < ?", 500),
+			param: ParamAggregate{MerchantID: sql.NullInt64{Valid: true, Int64: 1}},
+		},
+	}
+
+	for i, tc := range testCase {
+		t.Run(fmt.Sprintf("[%d] %s", i, tc.desc), func(t *testing.T) {
+			_, _, err := tc.qb.BuildUpdate(&SetProduct{Status: sql.NullString{Valid: true, String: "PAID"}}, &tc.param)
+			assert.ErrorIs(t, err, ErrInvalidParam)
+		})
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		param := ParamAggregate{
+			MerchantID: sql.NullInt64{Valid: true, Int64: 1},
+			TotalGTE:   sql.NullFloat64{Valid: true, Float64: 100},
+		}
+		_, _, err := New().BuildDelete(&param)
+		assert.ErrorIs(t, err, ErrInvalidParam)
+
+		_, _, err = New().GroupBy("merchant_id").BuildDelete(&ParamAggregate{MerchantID: sql.NullInt64{Valid: true, Int64: 1}})
+		assert.ErrorIs(t, err, ErrInvalidParam)
+	})
+}